@@ -0,0 +1,154 @@
+// Package cache maintains an in-memory index of parsed file state (symbols,
+// complexity metrics) so repeated MCP requests against an unchanged file
+// are O(1) instead of re-reading and re-parsing from disk every time.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alexkarsten/reducto/internal/walker"
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// Entry holds the cached state for one file, keyed by content hash so a
+// cached Symbols/Complexity is only ever served for the exact bytes it was
+// computed from.
+type Entry struct {
+	Hash       string
+	Symbols    []models.Symbol
+	Complexity *models.ComplexityMetrics
+	ModTime    time.Time
+}
+
+// changeEvent records when a path was last invalidated, so ChangedSince can
+// answer "what's changed since timestamp X" without re-walking the tree.
+type changeEvent struct {
+	path string
+	at   time.Time
+}
+
+// maxLogEntries bounds the change log so a long-running watch doesn't grow
+// it without limit; only recent history is useful for ChangedSince queries.
+const maxLogEntries = 2000
+
+// Cache is safe for concurrent use.
+type Cache struct {
+	mu      sync.RWMutex
+	entries map[string]*Entry
+	log     []changeEvent
+}
+
+// New returns an empty Cache.
+func New() *Cache {
+	return &Cache{entries: make(map[string]*Entry)}
+}
+
+// Symbols returns the cached symbols for path if hash still matches what's
+// on disk.
+func (c *Cache) Symbols(path, hash string) ([]models.Symbol, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[path]
+	if !ok || e.Hash != hash || e.Symbols == nil {
+		return nil, false
+	}
+	return e.Symbols, true
+}
+
+// PutSymbols caches symbols for path under hash, discarding any entry
+// computed from a different hash.
+func (c *Cache) PutSymbols(path, hash string, symbols []models.Symbol) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entryLocked(path, hash).Symbols = symbols
+}
+
+// Complexity returns the cached complexity metrics for path if hash still
+// matches what's on disk.
+func (c *Cache) Complexity(path, hash string) (models.ComplexityMetrics, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[path]
+	if !ok || e.Hash != hash || e.Complexity == nil {
+		return models.ComplexityMetrics{}, false
+	}
+	return *e.Complexity, true
+}
+
+// PutComplexity caches metrics for path under hash, discarding any entry
+// computed from a different hash.
+func (c *Cache) PutComplexity(path, hash string, metrics models.ComplexityMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entryLocked(path, hash).Complexity = &metrics
+}
+
+func (c *Cache) entryLocked(path, hash string) *Entry {
+	e, ok := c.entries[path]
+	if !ok || e.Hash != hash {
+		e = &Entry{Hash: hash, ModTime: time.Now()}
+		c.entries[path] = e
+	}
+	return e
+}
+
+// Invalidate drops any cached state for path and records the invalidation
+// in the change log for ChangedSince.
+func (c *Cache) Invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, path)
+
+	c.log = append(c.log, changeEvent{path: path, at: time.Now()})
+	if len(c.log) > maxLogEntries {
+		c.log = c.log[len(c.log)-maxLogEntries:]
+	}
+}
+
+// ChangedSince returns the (deduplicated) paths invalidated after t,
+// newest occurrence order, for list_files's changed_since filter.
+func (c *Cache) ChangedSince(t time.Time) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	seen := make(map[string]bool, len(c.log))
+	var paths []string
+	for i := len(c.log) - 1; i >= 0; i-- {
+		ev := c.log[i]
+		if ev.at.Before(t) {
+			break
+		}
+		if !seen[ev.path] {
+			seen[ev.path] = true
+			paths = append(paths, ev.path)
+		}
+	}
+	return paths
+}
+
+// Run subscribes to w's filesystem watch on root and invalidates cache
+// entries as files change, until ctx is cancelled.
+func (c *Cache) Run(ctx context.Context, w *walker.Walker, root string) error {
+	changes, err := w.Watch(ctx, root)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for cs := range changes {
+			for _, f := range cs.Added {
+				c.Invalidate(f.Path)
+			}
+			for _, f := range cs.Modified {
+				c.Invalidate(f.Path)
+			}
+			for _, f := range cs.Removed {
+				c.Invalidate(f.Path)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestSymbolsRoundTripsUntilInvalidated(t *testing.T) {
+	c := New()
+	symbols := []models.Symbol{{Name: "Foo"}}
+
+	c.PutSymbols("a.go", "hash1", symbols)
+
+	got, ok := c.Symbols("a.go", "hash1")
+	if !ok || len(got) != 1 || got[0].Name != "Foo" {
+		t.Fatalf("Symbols() = %+v, %v, want cached Foo", got, ok)
+	}
+
+	if _, ok := c.Symbols("a.go", "hash2"); ok {
+		t.Error("Symbols() should miss when hash doesn't match")
+	}
+
+	c.Invalidate("a.go")
+	if _, ok := c.Symbols("a.go", "hash1"); ok {
+		t.Error("Symbols() should miss after Invalidate")
+	}
+}
+
+func TestComplexityRoundTrips(t *testing.T) {
+	c := New()
+	metrics := models.ComplexityMetrics{CyclomaticComplexity: 3}
+
+	c.PutComplexity("a.go", "hash1", metrics)
+
+	got, ok := c.Complexity("a.go", "hash1")
+	if !ok || got.CyclomaticComplexity != 3 {
+		t.Fatalf("Complexity() = %+v, %v, want CyclomaticComplexity=3", got, ok)
+	}
+}
+
+func TestChangedSinceReturnsRecentInvalidations(t *testing.T) {
+	c := New()
+	before := time.Now()
+
+	c.Invalidate("a.go")
+	c.Invalidate("b.go")
+	c.Invalidate("a.go")
+
+	changed := c.ChangedSince(before)
+	if len(changed) != 2 {
+		t.Fatalf("ChangedSince() = %v, want 2 distinct paths", changed)
+	}
+
+	after := time.Now()
+	if got := c.ChangedSince(after); len(got) != 0 {
+		t.Errorf("ChangedSince(after) = %v, want none", got)
+	}
+}
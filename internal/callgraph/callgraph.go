@@ -0,0 +1,208 @@
+// Package callgraph builds a directed function-to-function call graph
+// across a project from LSP call hierarchy queries, so reducto can prune
+// unreferenced or leaf subgraphs in one pass instead of issuing per-symbol
+// FindReferences/GoToDefinition queries in isolation.
+package callgraph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/alexkarsten/reducto/internal/lsp"
+)
+
+// Node identifies a function/method by its declaring location.
+type Node struct {
+	URI  string
+	Name string
+}
+
+func (n Node) key() string {
+	return n.URI + "#" + n.Name
+}
+
+// Graph is a directed caller -> callee function graph.
+type Graph struct {
+	mu    sync.RWMutex
+	nodes map[string]Node
+	edges map[string]map[string]bool
+}
+
+// NewGraph returns an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{
+		nodes: make(map[string]Node),
+		edges: make(map[string]map[string]bool),
+	}
+}
+
+// AddNode registers n in the graph even if it has no edges yet, so that a
+// node with neither callers nor callees (e.g. a seed that turns out to call
+// nothing and is called by nothing) still shows up in Nodes/Unreferenced.
+func (g *Graph) AddNode(n Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.nodes[n.key()] = n
+}
+
+// AddEdge records that caller invokes callee, adding both as nodes if new.
+func (g *Graph) AddEdge(caller, callee Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.nodes[caller.key()] = caller
+	g.nodes[callee.key()] = callee
+
+	if g.edges[caller.key()] == nil {
+		g.edges[caller.key()] = make(map[string]bool)
+	}
+	g.edges[caller.key()][callee.key()] = true
+}
+
+// Callees returns the functions n calls.
+func (g *Graph) Callees(n Node) []Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var out []Node
+	for key := range g.edges[n.key()] {
+		out = append(out, g.nodes[key])
+	}
+	return out
+}
+
+// Callers returns the functions that call n.
+func (g *Graph) Callers(n Node) []Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var out []Node
+	for callerKey, callees := range g.edges {
+		if callees[n.key()] {
+			out = append(out, g.nodes[callerKey])
+		}
+	}
+	return out
+}
+
+// Unreferenced returns nodes with no callers, i.e. candidates for pruning
+// unless they're an entry point (main, exported API, test, etc.) — callers
+// of Unreferenced are expected to apply that filter themselves, since the
+// graph has no notion of visibility or entry points.
+func (g *Graph) Unreferenced() []Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	hasCaller := make(map[string]bool)
+	for _, callees := range g.edges {
+		for callee := range callees {
+			hasCaller[callee] = true
+		}
+	}
+
+	var out []Node
+	for key, node := range g.nodes {
+		if !hasCaller[key] {
+			out = append(out, node)
+		}
+	}
+	return out
+}
+
+// Nodes returns every node currently in the graph.
+func (g *Graph) Nodes() []Node {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	out := make([]Node, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		out = append(out, n)
+	}
+	return out
+}
+
+// ContentHasher returns a cheap fingerprint for uri's current content, used
+// to invalidate the Builder's memoized call hierarchy lookups when a file
+// changes between runs.
+type ContentHasher func(uri string) (string, error)
+
+// Builder walks call hierarchy edges outward from a set of seed symbols to
+// build a Graph, memoizing visited (URI, symbol name, content hash) triples
+// so re-running on an unchanged large repo is cheap.
+type Builder struct {
+	client lsp.Client
+	hash   ContentHasher
+
+	mu      sync.Mutex
+	visited map[string]bool
+}
+
+// NewBuilder returns a Builder that queries client for call hierarchy data
+// and uses hash to fingerprint file content for memoization.
+func NewBuilder(client lsp.Client, hash ContentHasher) *Builder {
+	return &Builder{
+		client:  client,
+		hash:    hash,
+		visited: make(map[string]bool),
+	}
+}
+
+func (b *Builder) visitKey(item lsp.CallHierarchyItem) (string, error) {
+	content, err := b.hash(item.URI)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s|%s|%s", item.URI, item.Name, content), nil
+}
+
+// Build performs a breadth-first walk of the call hierarchy starting at
+// seeds, recording every caller->callee edge it discovers into a Graph.
+func (b *Builder) Build(ctx context.Context, seeds []lsp.CallHierarchyItem) (*Graph, error) {
+	graph := NewGraph()
+	queue := append([]lsp.CallHierarchyItem{}, seeds...)
+
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		key, err := b.visitKey(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", item.URI, err)
+		}
+
+		b.mu.Lock()
+		if b.visited[key] {
+			b.mu.Unlock()
+			continue
+		}
+		b.visited[key] = true
+		b.mu.Unlock()
+
+		node := Node{URI: item.URI, Name: item.Name}
+		graph.AddNode(node)
+
+		outgoing, err := b.client.OutgoingCalls(ctx, item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch outgoing calls for %s: %w", item.Name, err)
+		}
+		for _, call := range outgoing {
+			callee := Node{URI: call.To.URI, Name: call.To.Name}
+			graph.AddEdge(node, callee)
+			queue = append(queue, call.To)
+		}
+
+		incoming, err := b.client.IncomingCalls(ctx, item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch incoming calls for %s: %w", item.Name, err)
+		}
+		for _, call := range incoming {
+			caller := Node{URI: call.From.URI, Name: call.From.Name}
+			graph.AddEdge(caller, node)
+			queue = append(queue, call.From)
+		}
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,142 @@
+package callgraph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexkarsten/reducto/internal/lsp"
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// fakeClient implements lsp.Client with a fixed, in-memory call graph:
+// main -> helper, and unused is never called by anything.
+type fakeClient struct {
+	outgoing map[string][]lsp.CallHierarchyOutgoingCall
+	incoming map[string][]lsp.CallHierarchyIncomingCall
+}
+
+func (f *fakeClient) Initialize(ctx context.Context, rootURI string) error { return nil }
+func (f *fakeClient) FindReferences(ctx context.Context, uri string, line, column int) ([]lsp.Reference, error) {
+	return nil, nil
+}
+func (f *fakeClient) GoToDefinition(ctx context.Context, uri string, line, column int) (*lsp.Definition, error) {
+	return nil, nil
+}
+func (f *fakeClient) DidOpen(uri, content string) error { return nil }
+func (f *fakeClient) DidClose(uri string) error         { return nil }
+func (f *fakeClient) Diagnostics(ctx context.Context, uri string) ([]lsp.Diagnostic, error) {
+	return nil, nil
+}
+func (f *fakeClient) CodeActions(ctx context.Context, uri string, rng lsp.Range, only []string) ([]lsp.CodeAction, error) {
+	return nil, nil
+}
+func (f *fakeClient) PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]lsp.CallHierarchyItem, error) {
+	return nil, nil
+}
+func (f *fakeClient) IncomingCalls(ctx context.Context, item lsp.CallHierarchyItem) ([]lsp.CallHierarchyIncomingCall, error) {
+	return f.incoming[item.Name], nil
+}
+func (f *fakeClient) OutgoingCalls(ctx context.Context, item lsp.CallHierarchyItem) ([]lsp.CallHierarchyOutgoingCall, error) {
+	return f.outgoing[item.Name], nil
+}
+func (f *fakeClient) Rename(ctx context.Context, uri string, line, column int, newName string) (*lsp.WorkspaceEdit, error) {
+	return nil, nil
+}
+func (f *fakeClient) WorkspaceSymbols(ctx context.Context, query string) ([]lsp.SymbolInformation, error) {
+	return nil, nil
+}
+func (f *fakeClient) DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error) {
+	return nil, nil
+}
+func (f *fakeClient) DocumentSymbols(ctx context.Context, uri string) ([]lsp.DocumentSymbol, error) {
+	return nil, nil
+}
+func (f *fakeClient) FoldingRange(ctx context.Context, uri string) ([]lsp.FoldingRange, error) {
+	return nil, nil
+}
+func (f *fakeClient) Hover(ctx context.Context, uri string, line, column int) (string, error) {
+	return "", nil
+}
+func (f *fakeClient) Shutdown() error     { return nil }
+func (f *fakeClient) IsInitialized() bool { return true }
+
+func item(name string) lsp.CallHierarchyItem {
+	return lsp.CallHierarchyItem{Name: name, URI: "file:///app.go"}
+}
+
+func TestBuilderBuildsGraphAndFindsUnreferenced(t *testing.T) {
+	client := &fakeClient{
+		outgoing: map[string][]lsp.CallHierarchyOutgoingCall{
+			"main":   {{To: item("helper")}},
+			"helper": {},
+			"unused": {},
+		},
+		incoming: map[string][]lsp.CallHierarchyIncomingCall{
+			"main":   {},
+			"helper": {{From: item("main")}},
+			"unused": {},
+		},
+	}
+
+	constantHash := func(uri string) (string, error) { return "v1", nil }
+	builder := NewBuilder(client, constantHash)
+
+	graph, err := builder.Build(context.Background(), []lsp.CallHierarchyItem{item("main"), item("unused")})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	callees := graph.Callees(Node{URI: "file:///app.go", Name: "main"})
+	if len(callees) != 1 || callees[0].Name != "helper" {
+		t.Errorf("Callees(main) = %+v, want [helper]", callees)
+	}
+
+	var unreferencedNames []string
+	for _, n := range graph.Unreferenced() {
+		unreferencedNames = append(unreferencedNames, n.Name)
+	}
+	found := false
+	for _, name := range unreferencedNames {
+		if name == "unused" {
+			found = true
+		}
+		if name == "helper" {
+			t.Errorf("Unreferenced() unexpectedly includes helper, which main calls")
+		}
+	}
+	if !found {
+		t.Errorf("Unreferenced() = %v, want it to include unused", unreferencedNames)
+	}
+}
+
+func TestBuilderMemoizesVisitedNodes(t *testing.T) {
+	calls := 0
+	client := &fakeClient{
+		outgoing: map[string][]lsp.CallHierarchyOutgoingCall{
+			"a": {{To: item("b")}},
+			"b": {{To: item("a")}},
+		},
+		incoming: map[string][]lsp.CallHierarchyIncomingCall{
+			"a": {},
+			"b": {},
+		},
+	}
+
+	hash := func(uri string) (string, error) {
+		calls++
+		return "v1", nil
+	}
+
+	builder := NewBuilder(client, hash)
+	if _, err := builder.Build(context.Background(), []lsp.CallHierarchyItem{item("a")}); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// a is popped from the queue twice (once as a seed, once via b's
+	// outgoing edge back to a) but only processed once; b once. Each pop
+	// costs one hash call to form its memoization key, even when the
+	// result turns out to already be visited.
+	if calls != 3 {
+		t.Errorf("hash called %d times, want 3", calls)
+	}
+}
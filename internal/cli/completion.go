@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/alexkarsten/reducto/internal/hub"
+	"github.com/spf13/cobra"
+)
+
+// builtinPatterns are the design patterns the sidecar's pattern injector
+// recognizes out of the box, independent of anything installed via the hub.
+var builtinPatterns = []string{
+	"factory", "strategy", "observer", "singleton", "builder", "adapter", "decorator",
+}
+
+// knownRemoteModels is a static fallback used for --model completion when
+// the user isn't preferring local Ollama models, since there's no API to
+// enumerate a provider's catalog without making a network call.
+var knownRemoteModels = []string{
+	"gpt-4o", "gpt-4o-mini", "claude-3-5-sonnet-20241022", "claude-3-5-haiku-20241022",
+}
+
+func completePatternNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := append([]string{}, builtinPatterns...)
+
+	if dir, err := hub.DefaultIndexDir(); err == nil {
+		if idx, err := hub.NewIndex(dir); err == nil {
+			if items, err := idx.List(hub.ItemTypePattern); err == nil {
+				for _, item := range items {
+					names = append(names, "hub:"+item.Name)
+				}
+			}
+		}
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeSessionIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	entries, err := os.ReadDir(".reducto")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var ids []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, "reducto-report-") || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(name, "reducto-report-"), ".md")
+		ids = append(ids, id)
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeModelNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if preferRemote {
+		return knownRemoteModels, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	out, err := exec.Command("ollama", "list").Output()
+	if err != nil {
+		return knownRemoteModels, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for i, line := range strings.Split(string(out), "\n") {
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeDirectories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveFilterDirs
+}
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `To load completions:
+
+Bash:
+  $ source <(reducto completion bash)
+
+Zsh:
+  $ reducto completion zsh > "${fpath[1]}/_reducto"
+
+Fish:
+  $ reducto completion fish | source
+
+PowerShell:
+  PS> reducto completion powershell | Out-String | Invoke-Expression`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletion(os.Stdout)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		return nil
+	},
+}
+
+func initCompletionCommands() {
+	rootCmd.AddCommand(completionCmd)
+
+	patternCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completePatternNames(cmd, args, toComplete)
+		}
+		return completeDirectories(cmd, args, toComplete)
+	}
+
+	reportCmd.RegisterFlagCompletionFunc("session", completeSessionIDs)
+	rootCmd.RegisterFlagCompletionFunc("model", completeModelNames)
+
+	analyzeCmd.ValidArgsFunction = completeDirectories
+	deduplicateCmd.ValidArgsFunction = completeDirectories
+	idiomatizeCmd.ValidArgsFunction = completeDirectories
+	checkCmd.ValidArgsFunction = completeDirectories
+	mcpCmd.ValidArgsFunction = completeDirectories
+}
+
+func init() {
+	initCompletionCommands()
+}
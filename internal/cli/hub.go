@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexkarsten/reducto/internal/hub"
+	"github.com/spf13/cobra"
+)
+
+func parseHubRef(ref string) (hub.ItemType, string, error) {
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid hub reference %q, expected type/name (e.g. pattern/long-method)", ref)
+	}
+	return hub.ItemType(parts[0]), parts[1], nil
+}
+
+func openHubIndex() (*hub.Index, error) {
+	dir, err := hub.DefaultIndexDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve hub index directory: %w", err)
+	}
+	return hub.NewIndex(dir)
+}
+
+func runHubList(itemType string) error {
+	idx, err := openHubIndex()
+	if err != nil {
+		return err
+	}
+
+	items, err := idx.List(hub.ItemType(itemType))
+	if err != nil {
+		return fmt.Errorf("failed to list hub items: %w", err)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No hub items installed.")
+		return nil
+	}
+
+	for _, item := range items {
+		taint := ""
+		if item.Tainted {
+			taint = " (tainted)"
+		}
+		fmt.Printf("%-30s %-10s%s\n", item.Ref(), item.Version, taint)
+	}
+	return nil
+}
+
+func runHubInstall(ref string) error {
+	itemType, name, err := parseHubRef(ref)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := hub.FetchManifest(cfg.Hub.IndexURL, cfg.Hub.TrustedKeys)
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range manifest.Items {
+		if candidate.Type == itemType && candidate.Name == name {
+			idx, err := openHubIndex()
+			if err != nil {
+				return err
+			}
+			if err := idx.Install(candidate); err != nil {
+				return fmt.Errorf("failed to install %s: %w", ref, err)
+			}
+			fmt.Printf("Installed %s/%s@%s\n", candidate.Type, candidate.Name, candidate.Version)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s not found in hub index %s", ref, cfg.Hub.IndexURL)
+}
+
+func runHubUpgrade(name string, force bool) error {
+	idx, err := openHubIndex()
+	if err != nil {
+		return err
+	}
+
+	manifest, err := hub.FetchManifest(cfg.Hub.IndexURL, cfg.Hub.TrustedKeys)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		installed, err := idx.List("")
+		if err != nil {
+			return err
+		}
+		for _, item := range installed {
+			if err := idx.Upgrade(item.Type, item.Name, manifest, force); err != nil {
+				fmt.Printf("skip %s: %v\n", item.Ref(), err)
+				continue
+			}
+			fmt.Printf("Upgraded %s\n", item.Ref())
+		}
+		return nil
+	}
+
+	itemType, itemName, err := parseHubRef(name)
+	if err != nil {
+		return err
+	}
+	if err := idx.Upgrade(itemType, itemName, manifest, force); err != nil {
+		return err
+	}
+	fmt.Printf("Upgraded %s/%s\n", itemType, itemName)
+	return nil
+}
+
+func runHubRemove(ref string) error {
+	itemType, name, err := parseHubRef(ref)
+	if err != nil {
+		return err
+	}
+
+	idx, err := openHubIndex()
+	if err != nil {
+		return err
+	}
+	if err := idx.Remove(itemType, name); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", ref, err)
+	}
+	fmt.Printf("Removed %s\n", ref)
+	return nil
+}
+
+// resolveHubPattern resolves a "hub:pattern/<name>" reference passed to
+// `reducto pattern` into the underlying installed item's content path, so
+// patternCmd can consult rules installed via `reducto hub install` instead
+// of only the sidecar's built-in pattern library.
+func resolveHubPattern(name string) (string, error) {
+	idx, err := openHubIndex()
+	if err != nil {
+		return "", err
+	}
+
+	item, err := idx.Inspect(hub.ItemTypePattern, name)
+	if err != nil {
+		return "", fmt.Errorf("hub pattern %q is not installed (try `reducto hub install pattern/%s`): %w", name, name, err)
+	}
+
+	dir, err := hub.DefaultIndexDir()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%s/content", dir, hub.ItemTypePattern, item.Name), nil
+}
+
+func runHubInspect(ref string) error {
+	itemType, name, err := parseHubRef(ref)
+	if err != nil {
+		return err
+	}
+
+	idx, err := openHubIndex()
+	if err != nil {
+		return err
+	}
+	item, err := idx.Inspect(itemType, name)
+	if err != nil {
+		return fmt.Errorf("failed to inspect %s: %w", ref, err)
+	}
+
+	fmt.Printf("Name:         %s\n", item.Name)
+	fmt.Printf("Type:         %s\n", item.Type)
+	fmt.Printf("Language:     %s\n", item.Language)
+	fmt.Printf("Version:      %s\n", item.Version)
+	fmt.Printf("Source:       %s\n", item.Source)
+	fmt.Printf("SHA256:       %s\n", item.SHA256)
+	if len(item.Dependencies) > 0 {
+		fmt.Printf("Dependencies: %s\n", strings.Join(item.Dependencies, ", "))
+	}
+	fmt.Printf("Tainted:      %t\n", item.Tainted)
+	return nil
+}
+
+var hubCmd = &cobra.Command{
+	Use:   "hub",
+	Short: "Manage installable pattern, idiom, dedup, and check packs",
+	Long: `The hub is a CrowdSec-style registry of named, versioned packs of
+design-pattern detectors, idiomatization recipes, deduplication heuristics,
+and quality checks that can be installed without recompiling reducto.`,
+}
+
+var hubListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List installed hub items",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		itemType, _ := cmd.Flags().GetString("type")
+		return runHubList(itemType)
+	},
+}
+
+var hubInstallCmd = &cobra.Command{
+	Use:   "install <type>/<name>",
+	Short: "Install a hub item by reference, e.g. pattern/long-method",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHubInstall(args[0])
+	},
+}
+
+var hubUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [<type>/<name>]",
+	Short: "Upgrade one or all installed hub items to their latest published version",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		return runHubUpgrade(name, force)
+	},
+}
+
+var hubRemoveCmd = &cobra.Command{
+	Use:   "remove <type>/<name>",
+	Short: "Remove an installed hub item",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHubRemove(args[0])
+	},
+}
+
+var hubInspectCmd = &cobra.Command{
+	Use:   "inspect <type>/<name>",
+	Short: "Show details and taint status for an installed hub item",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHubInspect(args[0])
+	},
+}
+
+func initHubCommands() {
+	hubListCmd.Flags().String("type", "", "filter by item type (pattern, idiom, dedup, check)")
+	hubUpgradeCmd.Flags().Bool("force", false, "overwrite locally modified (tainted) items")
+
+	hubCmd.AddCommand(hubListCmd)
+	hubCmd.AddCommand(hubInstallCmd)
+	hubCmd.AddCommand(hubUpgradeCmd)
+	hubCmd.AddCommand(hubRemoveCmd)
+	hubCmd.AddCommand(hubInspectCmd)
+
+	rootCmd.AddCommand(hubCmd)
+}
+
+func init() {
+	initHubCommands()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -24,6 +25,7 @@ var (
 	preferLocal  bool
 	preferRemote bool
 	cfg          *models.Config
+	cfgLoader    *config.Loader
 	mcpManager   *sidecar.MCPManager
 )
 
@@ -37,7 +39,8 @@ It identifies repeating patterns, suggests idiomatic improvements,
 and applies design patterns to reduce cognitive load.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		var err error
-		cfg, err = config.Load(cfgFile)
+		cfgLoader = config.NewLoader(cfgFile)
+		cfg, err = cfgLoader.Load()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
@@ -124,6 +127,7 @@ func showSpinner(done <-chan struct{}, inProgress string, complete string) <-cha
 }
 
 func runAnalyze(path string) error {
+	cfg := cfgLoader.Current()
 	fmt.Printf("Analyzing repository...\n")
 
 	done := make(chan struct{})
@@ -154,6 +158,7 @@ func runAnalyze(path string) error {
 }
 
 func runAnalyzeWithReport(path string) error {
+	cfg := cfgLoader.Current()
 	fmt.Printf("Analyzing repository...\n")
 
 	done := make(chan struct{})
@@ -193,7 +198,7 @@ func runAnalyzeWithReport(path string) error {
 		}
 	}
 
-	rep := reporter.New(cfg)
+	rep := reporter.New(config.Static(cfg))
 	if err := rep.GenerateBaseline(baseline); err != nil {
 		return fmt.Errorf("failed to generate baseline report: %w", err)
 	}
@@ -203,6 +208,7 @@ func runAnalyzeWithReport(path string) error {
 }
 
 func runDeduplicate(path string, commitChanges bool, generateReport bool, dryRun bool) error {
+	cfg := cfgLoader.Current()
 	if dryRun {
 		fmt.Println("=== DRY RUN MODE - No changes will be applied ===")
 	} else {
@@ -226,7 +232,7 @@ func runDeduplicate(path string, commitChanges bool, generateReport bool, dryRun
 	}
 
 	if dryRun {
-		rep := reporter.New(cfg)
+		rep := reporter.New(config.Static(cfg))
 		return rep.GenerateDryRun(plan, "deduplicate", path)
 	}
 
@@ -267,6 +273,7 @@ func runDeduplicate(path string, commitChanges bool, generateReport bool, dryRun
 }
 
 func runIdiomatize(path string, dryRun bool) error {
+	cfg := cfgLoader.Current()
 	if dryRun {
 		fmt.Println("=== DRY RUN MODE - No changes will be applied ===")
 	} else {
@@ -290,7 +297,7 @@ func runIdiomatize(path string, dryRun bool) error {
 	}
 
 	if dryRun {
-		rep := reporter.New(cfg)
+		rep := reporter.New(config.Static(cfg))
 		return rep.GenerateDryRun(plan, "idiomatize", path)
 	}
 
@@ -322,6 +329,7 @@ func runIdiomatize(path string, dryRun bool) error {
 }
 
 func runPattern(pattern, path string, dryRun bool) error {
+	cfg := cfgLoader.Current()
 	if dryRun {
 		fmt.Println("=== DRY RUN MODE - No changes will be applied ===")
 	} else {
@@ -330,6 +338,14 @@ func runPattern(pattern, path string, dryRun bool) error {
 		}
 	}
 
+	if strings.HasPrefix(pattern, "hub:") {
+		resolved, err := resolveHubPattern(strings.TrimPrefix(pattern, "hub:"))
+		if err != nil {
+			return err
+		}
+		pattern = resolved
+	}
+
 	if pattern != "" {
 		fmt.Printf("Applying pattern: %s\n", pattern)
 	} else {
@@ -349,7 +365,7 @@ func runPattern(pattern, path string, dryRun bool) error {
 	}
 
 	if dryRun {
-		rep := reporter.New(cfg)
+		rep := reporter.New(config.Static(cfg))
 		return rep.GenerateDryRun(plan, "pattern", path)
 	}
 
@@ -382,10 +398,71 @@ func runPattern(pattern, path string, dryRun bool) error {
 }
 
 func runReport(sessionID string) error {
-	rep := reporter.New(cfg)
+	rep := reporter.New(config.Static(cfgLoader.Current()))
 	return rep.Load(sessionID)
 }
 
+func runBaselineCheck(path, against string, maxNewHotspots, maxWorsened int) error {
+	cfg := cfgLoader.Current()
+	fmt.Printf("Running baseline scan...\n")
+
+	mcpManager = sidecar.NewMCPManager(path, cfg)
+	result, err := mcpManager.Analyze(path)
+	if err != nil {
+		return fmt.Errorf("baseline scan failed: %w", err)
+	}
+
+	curr := &reporter.BaselineResult{
+		TotalFiles:   result.TotalFiles,
+		TotalSymbols: result.TotalSymbols,
+		Hotspots:     make([]reporter.ComplexityHotspot, len(result.Hotspots)),
+	}
+	for i, hs := range result.Hotspots {
+		curr.Hotspots[i] = reporter.ComplexityHotspot{
+			File:                 hs.File,
+			Line:                 hs.Line,
+			Symbol:               hs.Symbol,
+			CyclomaticComplexity: hs.CyclomaticComplexity,
+			CognitiveComplexity:  hs.CognitiveComplexity,
+		}
+	}
+
+	rep := reporter.New(config.Static(cfg))
+
+	prevSessionID := against
+	if prevSessionID == "latest" {
+		prevSessionID = ""
+	}
+	prev, err := rep.LoadBaseline(prevSessionID)
+	if err != nil {
+		fmt.Printf("No previous baseline found (%v); recording this scan as the new baseline.\n", err)
+		return rep.GenerateBaseline(curr)
+	}
+
+	if err := rep.GenerateBaseline(curr); err != nil {
+		return fmt.Errorf("failed to persist baseline: %w", err)
+	}
+
+	diff, err := rep.CompareBaselines(prev, curr)
+	if err != nil {
+		return fmt.Errorf("failed to compare baselines: %w", err)
+	}
+
+	if err := rep.GenerateBaselineDiff(diff); err != nil {
+		return fmt.Errorf("failed to generate baseline diff report: %w", err)
+	}
+
+	fmt.Printf("New: %d  Removed: %d  Worsened: %d  Improved: %d  Unchanged: %d\n",
+		diff.NewCount, diff.RemovedCount, diff.WorsenedCount, diff.ImprovedCount, diff.UnchangedCount)
+
+	if diff.NewCount > maxNewHotspots || diff.WorsenedCount > maxWorsened {
+		return fmt.Errorf("complexity gate failed: %d new hotspots (max %d), %d worsened (max %d)",
+			diff.NewCount, maxNewHotspots, diff.WorsenedCount, maxWorsened)
+	}
+
+	return nil
+}
+
 func runMCP(path string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -400,10 +477,21 @@ func runMCP(path string) error {
 	server := mcp.NewServer(path)
 	defer server.Shutdown()
 
+	reloads, err := cfgLoader.Watch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch config: %w", err)
+	}
+	go func() {
+		for cfg := range reloads {
+			server.ApplyConfig(cfg)
+		}
+	}()
+
 	return server.Start(ctx, os.Stdin, os.Stdout)
 }
 
 func runCheck(path string) error {
+	cfg := cfgLoader.Current()
 	fmt.Printf("Checking code quality...\n")
 
 	done := make(chan struct{})
@@ -575,6 +663,32 @@ var reportCmd = &cobra.Command{
 	},
 }
 
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Inspect and compare complexity baselines",
+}
+
+var baselineCheckCmd = &cobra.Command{
+	Use:   "check [path]",
+	Short: "Fail if complexity regressed past a previous baseline",
+	Long: `Runs a fresh baseline scan and compares it against a previously
+generated baseline, exiting non-zero if it finds more new hotspots or
+worsened symbols than the configured limits. Intended for CI.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		against, _ := cmd.Flags().GetString("against")
+		maxNewHotspots, _ := cmd.Flags().GetInt("max-new-hotspots")
+		maxWorsened, _ := cmd.Flags().GetInt("max-worsened")
+
+		return runBaselineCheck(path, against, maxNewHotspots, maxWorsened)
+	},
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number",
@@ -635,6 +749,9 @@ func initCommands() {
 	patternCmd.Flags().Bool("report", false, "generate report after pattern injection")
 	patternCmd.Flags().Bool("dry-run", false, "show proposed changes without applying")
 	reportCmd.Flags().StringP("session", "s", "", "session ID to report (default: last session)")
+	baselineCheckCmd.Flags().String("against", "latest", "baseline session ID to compare against, or \"latest\"")
+	baselineCheckCmd.Flags().Int("max-new-hotspots", 0, "fail if more than this many new hotspots appear")
+	baselineCheckCmd.Flags().Int("max-worsened", 0, "fail if more than this many hotspots worsen")
 
 	rootCmd.AddCommand(analyzeCmd)
 	rootCmd.AddCommand(deduplicateCmd)
@@ -644,6 +761,9 @@ func initCommands() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(mcpCmd)
 	rootCmd.AddCommand(checkCmd)
+
+	baselineCmd.AddCommand(baselineCheckCmd)
+	rootCmd.AddCommand(baselineCmd)
 }
 
 func init() {
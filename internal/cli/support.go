@@ -0,0 +1,270 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/alexkarsten/reducto/internal/git"
+	"github.com/alexkarsten/reducto/internal/hub"
+	"github.com/alexkarsten/reducto/internal/sidecar"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// collector gathers one piece of diagnostic information for `support dump`.
+// A collector's own failure never aborts the dump: its error is recorded in
+// errors.txt and every other collector still runs, so a partially broken
+// environment still produces a useful bundle.
+type collector struct {
+	name string
+	fn   func(path string) (filename string, content []byte, err error)
+}
+
+func supportCollectors() []collector {
+	return []collector{
+		{"config", collectConfig},
+		{"version", collectVersionInfo},
+		{"git", collectGitState},
+		{"sidecar", collectSidecarState},
+		{"logs", collectOllamaModels},
+		{"reports", collectSessionReports},
+		{"hub", collectHubState},
+	}
+}
+
+func runSupportDump(path, output string, skip []string) error {
+	skipSet := make(map[string]bool, len(skip))
+	for _, s := range skip {
+		skipSet[strings.TrimSpace(s)] = true
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	var errLines []string
+	for _, c := range supportCollectors() {
+		if skipSet[c.name] {
+			continue
+		}
+
+		filename, content, err := c.fn(path)
+		if err != nil {
+			errLines = append(errLines, fmt.Sprintf("%s: %v", c.name, err))
+			continue
+		}
+		if content == nil {
+			continue
+		}
+
+		w, err := zw.Create(filename)
+		if err != nil {
+			errLines = append(errLines, fmt.Sprintf("%s: failed to add %s to archive: %v", c.name, filename, err))
+			continue
+		}
+		if _, err := w.Write(content); err != nil {
+			errLines = append(errLines, fmt.Sprintf("%s: failed to write %s: %v", c.name, filename, err))
+		}
+	}
+
+	if len(errLines) > 0 {
+		if w, err := zw.Create("errors.txt"); err == nil {
+			w.Write([]byte(strings.Join(errLines, "\n") + "\n"))
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize support bundle: %w", err)
+	}
+
+	if output == "-" {
+		_, err := io.Copy(os.Stdout, &buf)
+		return err
+	}
+
+	if output == "" {
+		output = "reducto-support.zip"
+	}
+	return os.WriteFile(output, buf.Bytes(), 0644)
+}
+
+// collectConfig writes a copy of the effective config with the per-model
+// API keys redacted before it goes into a support bundle.
+func collectConfig(path string) (string, []byte, error) {
+	scrubbed := *cfg
+	scrubbed.Models.Light.APIKey = redactSecret(scrubbed.Models.Light.APIKey)
+	scrubbed.Models.Medium.APIKey = redactSecret(scrubbed.Models.Medium.APIKey)
+	scrubbed.Models.Heavy.APIKey = redactSecret(scrubbed.Models.Heavy.APIKey)
+
+	out, err := yaml.Marshal(scrubbed)
+	if err != nil {
+		return "", nil, err
+	}
+	return "config.yaml", out, nil
+}
+
+func redactSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "***REDACTED***"
+}
+
+func collectVersionInfo(path string) (string, []byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "reducto: v0.1.0\n")
+	fmt.Fprintf(&b, "go: %s\n", runtime.Version())
+	fmt.Fprintf(&b, "os: %s\n", runtime.GOOS)
+	fmt.Fprintf(&b, "arch: %s\n", runtime.GOARCH)
+	return "version.txt", []byte(b.String()), nil
+}
+
+func collectGitState(path string) (string, []byte, error) {
+	gitMgr := git.NewManager(path)
+	if !gitMgr.IsRepo() {
+		return "git.txt", []byte(fmt.Sprintf("%s is not a git repository\n", path)), nil
+	}
+
+	var b strings.Builder
+	if branch, err := gitMgr.CurrentBranch(); err == nil {
+		fmt.Fprintf(&b, "branch: %s\n", branch)
+	} else {
+		fmt.Fprintf(&b, "branch: error: %v\n", err)
+	}
+	if commit, err := gitMgr.CurrentCommit(); err == nil {
+		fmt.Fprintf(&b, "commit: %s\n", commit)
+	} else {
+		fmt.Fprintf(&b, "commit: error: %v\n", err)
+	}
+	if clean, err := gitMgr.IsClean(); err == nil {
+		fmt.Fprintf(&b, "clean: %t\n", clean)
+	} else {
+		fmt.Fprintf(&b, "clean: error: %v\n", err)
+	}
+
+	return "git.txt", []byte(b.String()), nil
+}
+
+func collectSidecarState(path string) (string, []byte, error) {
+	info := sidecar.Diagnose()
+	var b strings.Builder
+	fmt.Fprintf(&b, "path: %s\n", info.Path)
+	fmt.Fprintf(&b, "version: %s\n", info.Version)
+	fmt.Fprintf(&b, "resolved: %t\n", info.Resolved)
+	if info.ResolveErr != "" {
+		fmt.Fprintf(&b, "resolve_error: %s\n", info.ResolveErr)
+	}
+	return "sidecar.txt", []byte(b.String()), nil
+}
+
+func collectOllamaModels(path string) (string, []byte, error) {
+	out, err := exec.Command("ollama", "list").CombinedOutput()
+	if err != nil {
+		return "", nil, fmt.Errorf("ollama list failed: %w", err)
+	}
+	return "ollama-models.txt", out, nil
+}
+
+// sessionReportsToKeep bounds how many recent session reports the support
+// bundle includes, so a long-lived project directory doesn't balloon the
+// archive.
+const sessionReportsToKeep = 10
+
+func collectSessionReports(path string) (string, []byte, error) {
+	reportDir := filepath.Join(path, ".reducto")
+	entries, err := os.ReadDir(reportDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), "reducto-report-") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	if len(names) > sessionReportsToKeep {
+		names = names[len(names)-sessionReportsToKeep:]
+	}
+
+	var b strings.Builder
+	for _, name := range names {
+		content, err := os.ReadFile(filepath.Join(reportDir, name))
+		if err != nil {
+			fmt.Fprintf(&b, "--- %s: error: %v ---\n", name, err)
+			continue
+		}
+		fmt.Fprintf(&b, "--- %s ---\n%s\n", name, content)
+	}
+	return "session-reports.txt", []byte(b.String()), nil
+}
+
+func collectHubState(path string) (string, []byte, error) {
+	dir, err := hub.DefaultIndexDir()
+	if err != nil {
+		return "", nil, err
+	}
+	idx, err := hub.NewIndex(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	items, err := idx.List("")
+	if err != nil {
+		return "", nil, err
+	}
+
+	out, err := yaml.Marshal(items)
+	if err != nil {
+		return "", nil, err
+	}
+	return "hub-state.yaml", out, nil
+}
+
+var supportCmd = &cobra.Command{
+	Use:   "support",
+	Short: "Diagnostic tools for bug reports",
+}
+
+var supportDumpCmd = &cobra.Command{
+	Use:   "dump [path]",
+	Short: "Bundle diagnostics (config, git state, sidecar diagnostics, hub state) for a bug report",
+	Long: `Produces a zip archive containing the effective config (secrets
+scrubbed), reducto and Go versions, git status for the target path, sidecar
+diagnostics, installed Ollama models, recent session reports, and hub state.
+Sidecar diagnostics are its path, version, and resolve status; sidecar logs
+are not included. Each collector's failure is recorded in errors.txt rather than aborting the
+dump, so a partially broken environment still produces useful output.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+		output, _ := cmd.Flags().GetString("output")
+		skip, _ := cmd.Flags().GetStringSlice("skip")
+		return runSupportDump(path, output, skip)
+	},
+}
+
+func initSupportCommands() {
+	supportDumpCmd.Flags().StringP("output", "o", "reducto-support.zip", "output file, or - to stream to stdout")
+	supportDumpCmd.Flags().StringSlice("skip", nil, "collectors to omit (e.g. logs,git)")
+
+	supportCmd.AddCommand(supportDumpCmd)
+	rootCmd.AddCommand(supportCmd)
+}
+
+func init() {
+	initSupportCommands()
+}
@@ -43,6 +43,10 @@ func DefaultConfig() *models.Config {
 			CognitiveComplexity:  15,
 			LinesOfCode:          50,
 		},
+		Hub: models.HubConfig{
+			IndexURL: "https://hub.reducto.dev/index.yaml",
+			Branch:   "main",
+		},
 		PreApprove:      false,
 		CommitChanges:   false,
 		Report:          false,
@@ -106,6 +110,10 @@ func setDefaults(v *viper.Viper, cfg *models.Config) {
 	v.SetDefault("complexity_thresholds.cognitive_complexity", cfg.ComplexityThresholds.CognitiveComplexity)
 	v.SetDefault("complexity_thresholds.lines_of_code", cfg.ComplexityThresholds.LinesOfCode)
 
+	v.SetDefault("hub.index_url", cfg.Hub.IndexURL)
+	v.SetDefault("hub.branch", cfg.Hub.Branch)
+	v.SetDefault("hub.trusted_keys", cfg.Hub.TrustedKeys)
+
 	v.SetDefault("pre_approve", cfg.PreApprove)
 	v.SetDefault("commit_changes", cfg.CommitChanges)
 	v.SetDefault("report", cfg.Report)
@@ -131,6 +139,7 @@ func Save(cfg *models.Config, path string) error {
 	v.Set("models", cfg.Models)
 	v.Set("sidecar", cfg.Sidecar)
 	v.Set("complexity_thresholds", cfg.ComplexityThresholds)
+	v.Set("hub", cfg.Hub)
 	v.Set("pre_approve", cfg.PreApprove)
 	v.Set("commit_changes", cfg.CommitChanges)
 	v.Set("report", cfg.Report)
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Loader loads and validates a models.Config, holds the most recently
+// loaded value behind an atomic pointer so concurrent readers never
+// observe a half-written Config, and can re-load and republish it on
+// SIGHUP or a write to the underlying config file. This lets long-running
+// invocations (the mcp server, a baseline watch) pick up threshold or
+// model tweaks without a restart.
+type Loader struct {
+	configPath string
+	current    atomic.Pointer[models.Config]
+
+	mu          sync.Mutex
+	subscribers []chan *models.Config
+}
+
+// NewLoader returns a Loader that reads from configPath, the same argument
+// accepted by Load; an empty configPath uses the default search path.
+func NewLoader(configPath string) *Loader {
+	return &Loader{configPath: configPath}
+}
+
+// Load reads and validates the config, stores it as Current, and returns
+// it.
+func (l *Loader) Load() (*models.Config, error) {
+	cfg, err := Load(l.configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	l.current.Store(cfg)
+	return cfg, nil
+}
+
+// Current returns the most recently loaded config, or nil if Load hasn't
+// been called yet.
+func (l *Loader) Current() *models.Config {
+	return l.current.Load()
+}
+
+// Static adapts a fixed config to the func() *models.Config signature
+// expected by reload-aware consumers like reporter.New, for callers that
+// already hold a snapshot (e.g. grabbed from Current() at the start of a
+// refactor operation) and want it to stay fixed for that operation's
+// lifetime even if a later reload swaps Loader's Current().
+func Static(cfg *models.Config) func() *models.Config {
+	return func() *models.Config { return cfg }
+}
+
+// Watch re-reads and republishes the config on SIGHUP, and on a write to
+// the config file when it can be resolved to a concrete path on disk. Each
+// call returns its own channel; delivery is a non-blocking send, so a
+// subscriber that isn't ready to receive simply misses that notification
+// (Current() always has the latest value regardless). The subscription
+// stops, and its channel is closed, when ctx is done.
+func (l *Loader) Watch(ctx context.Context) (<-chan *models.Config, error) {
+	ch := make(chan *models.Config, 1)
+
+	l.mu.Lock()
+	l.subscribers = append(l.subscribers, ch)
+	l.mu.Unlock()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	var fsw *fsnotify.Watcher
+	if path := l.resolvedConfigPath(); path != "" {
+		if w, err := fsnotify.NewWatcher(); err == nil {
+			if err := w.Add(filepath.Dir(path)); err == nil {
+				fsw = w
+			} else {
+				w.Close()
+			}
+		}
+	}
+
+	go l.watchLoop(ctx, sigCh, fsw, ch)
+
+	return ch, nil
+}
+
+func (l *Loader) watchLoop(ctx context.Context, sigCh chan os.Signal, fsw *fsnotify.Watcher, ch chan *models.Config) {
+	defer signal.Stop(sigCh)
+	defer l.removeSubscriber(ch)
+	defer close(ch)
+	if fsw != nil {
+		defer fsw.Close()
+	}
+
+	path := l.resolvedConfigPath()
+
+	for {
+		var events <-chan fsnotify.Event
+		var errs <-chan error
+		if fsw != nil {
+			events = fsw.Events
+			errs = fsw.Errors
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-sigCh:
+			l.reload()
+
+		case ev, ok := <-events:
+			if !ok {
+				fsw = nil
+				continue
+			}
+			if ev.Op&(fsnotify.Write|fsnotify.Create) != 0 && filepath.Clean(ev.Name) == filepath.Clean(path) {
+				l.reload()
+			}
+
+		case _, ok := <-errs:
+			if !ok {
+				fsw = nil
+			}
+		}
+	}
+}
+
+func (l *Loader) reload() {
+	cfg, err := l.Load()
+	if err != nil {
+		return
+	}
+	l.publish(cfg)
+}
+
+func (l *Loader) publish(cfg *models.Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, ch := range l.subscribers {
+		select {
+		case ch <- cfg:
+		default:
+		}
+	}
+}
+
+func (l *Loader) removeSubscriber(target chan *models.Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, ch := range l.subscribers {
+		if ch == target {
+			l.subscribers = append(l.subscribers[:i], l.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// resolvedConfigPath returns the concrete file Load would read, so Watch
+// knows what to fsnotify. It mirrors Load's own search order and returns ""
+// if nothing resolves, in which case Watch falls back to SIGHUP only.
+func (l *Loader) resolvedConfigPath() string {
+	if l.configPath != "" {
+		return l.configPath
+	}
+
+	if _, err := os.Stat(DefaultConfigName + "." + DefaultConfigType); err == nil {
+		return DefaultConfigName + "." + DefaultConfigType
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		candidate := filepath.Join(home, DefaultConfigName+"."+DefaultConfigType)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// validate rejects a config with out-of-range settings that Load's
+// defaults and viper's lenient parsing would otherwise let through silently.
+func validate(cfg *models.Config) error {
+	if cfg.Sidecar.Port < 0 || cfg.Sidecar.Port > 65535 {
+		return fmt.Errorf("sidecar.port %d out of range", cfg.Sidecar.Port)
+	}
+	if cfg.ComplexityThresholds.CyclomaticComplexity < 0 {
+		return fmt.Errorf("complexity_thresholds.cyclomatic_complexity must be >= 0")
+	}
+	if cfg.ComplexityThresholds.CognitiveComplexity < 0 {
+		return fmt.Errorf("complexity_thresholds.cognitive_complexity must be >= 0")
+	}
+	if cfg.LSPCache.MaxBytesMB < 0 {
+		return fmt.Errorf("lsp_cache.max_bytes_mb must be >= 0")
+	}
+	if cfg.LSPCache.TTLSeconds < 0 {
+		return fmt.Errorf("lsp_cache.ttl_seconds must be >= 0")
+	}
+	return nil
+}
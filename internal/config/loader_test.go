@@ -0,0 +1,131 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+const waitTimeout = 2 * time.Second
+
+func TestLoaderCurrentBeforeLoad(t *testing.T) {
+	l := NewLoader("")
+	if l.Current() != nil {
+		t.Error("Current() should be nil before Load() is called")
+	}
+}
+
+func TestLoaderLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test-config.yaml")
+	if err := os.WriteFile(configPath, []byte("pre_approve: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	l := NewLoader(configPath)
+	cfg, err := l.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.PreApprove {
+		t.Error("expected PreApprove to be true")
+	}
+	if l.Current() != cfg {
+		t.Error("Current() should return the just-loaded config")
+	}
+}
+
+func TestLoaderLoadRejectsInvalidConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test-config.yaml")
+	if err := os.WriteFile(configPath, []byte("sidecar:\n  port: 99999\n"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	l := NewLoader(configPath)
+	if _, err := l.Load(); err == nil {
+		t.Error("expected an error for an out-of-range sidecar.port")
+	}
+}
+
+func TestLoaderWatchSIGHUP(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test-config.yaml")
+	if err := os.WriteFile(configPath, []byte("sidecar:\n  port: 1111\n"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	l := NewLoader(configPath)
+	if _, err := l.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloads, err := l.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte("sidecar:\n  port: 2222\n"), 0644); err != nil {
+		t.Fatalf("failed to rewrite fixture: %v", err)
+	}
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatalf("failed to send SIGHUP: %v", err)
+	}
+
+	select {
+	case cfg := <-reloads:
+		if cfg.Sidecar.Port != 2222 {
+			t.Errorf("expected reloaded sidecar.port 2222, got %d", cfg.Sidecar.Port)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("timed out waiting for reload notification")
+	}
+
+	if l.Current().Sidecar.Port != 2222 {
+		t.Errorf("expected Current() to reflect the reload, got %d", l.Current().Sidecar.Port)
+	}
+}
+
+func TestLoaderWatchStopsOnContextDone(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test-config.yaml")
+	if err := os.WriteFile(configPath, []byte("pre_approve: false\n"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	l := NewLoader(configPath)
+	if _, err := l.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reloads, err := l.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-reloads:
+		if ok {
+			t.Error("expected the reload channel to be closed, got a value instead")
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("timed out waiting for the reload channel to close")
+	}
+}
+
+func TestStatic(t *testing.T) {
+	cfg := DefaultConfig()
+	get := Static(cfg)
+	if get() != cfg {
+		t.Error("Static() should always return the same snapshot")
+	}
+}
@@ -5,23 +5,67 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/alexkarsten/dehydrate/pkg/models"
+	"github.com/alexkarsten/reducto/pkg/models"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
 type Manager struct {
 	path string
 	repo *git.Repository
+
+	signer Signer
+
+	// trustedKeyRing, when set, makes RollbackTo refuse to reset onto a
+	// commit whose PGP signature doesn't verify against it.
+	trustedKeyRing string
+
+	blameMu    sync.Mutex
+	blameCache map[string][]BlameLine
 }
 
 func NewManager(path string) *Manager {
 	return &Manager{path: path}
 }
 
+// NewManagerWithSigner returns a Manager that signs every checkpoint,
+// commit, and stash it creates using signer, the way `git commit -S` signs
+// with whichever key gpg.signingkey/gpg.format point at.
+func NewManagerWithSigner(path string, signer Signer) *Manager {
+	return &Manager{path: path, signer: signer}
+}
+
+// SetTrustedKeyRing configures an armored OpenPGP keyring that RollbackTo
+// checks commits against before resetting onto them, refusing the
+// rollback if the target commit's signature doesn't verify. Pass "" (the
+// default) to disable the check.
+func (m *Manager) SetTrustedKeyRing(armoredKeyRing string) {
+	m.trustedKeyRing = armoredKeyRing
+}
+
+// commitOptions returns the CommitOptions common to CreateCheckpoint,
+// Commit, and Stash, wiring in m.signer when one is configured.
+func (m *Manager) commitOptions() *git.CommitOptions {
+	opts := &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "dehydrator",
+			Email: "dehydrator@local",
+		},
+	}
+	if m.signer != nil {
+		opts.Signer = goGitSigner{m.signer}
+	}
+	return opts
+}
+
 func (m *Manager) open() error {
 	if m.repo != nil {
 		return nil
@@ -107,12 +151,7 @@ func (m *Manager) CreateCheckpoint(message string) error {
 		}
 	}
 
-	_, err = wt.Commit(message, &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "dehydrator",
-			Email: "dehydrator@local",
-		},
-	})
+	_, err = wt.Commit(message, m.commitOptions())
 	if err != nil {
 		return fmt.Errorf("failed to commit: %w", err)
 	}
@@ -137,12 +176,7 @@ func (m *Manager) Commit(message string, changes []models.FileChange) error {
 		}
 	}
 
-	_, err = wt.Commit(message, &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "dehydrator",
-			Email: "dehydrator@local",
-		},
-	})
+	_, err = wt.Commit(message, m.commitOptions())
 	if err != nil {
 		return fmt.Errorf("failed to commit: %w", err)
 	}
@@ -186,6 +220,362 @@ func (m *Manager) Rollback() error {
 	return nil
 }
 
+// RollbackTo hard-resets the worktree to hash, which may be the abbreviated
+// form returned by CurrentCommit. Unlike Rollback, which always steps back
+// one commit from HEAD, this is used to return to an arbitrary checkpoint
+// recorded earlier, e.g. by a Session.
+func (m *Manager) RollbackTo(hash string) error {
+	if err := m.open(); err != nil {
+		return err
+	}
+
+	commitHash, err := m.repo.ResolveRevision(plumbing.Revision(hash))
+	if err != nil {
+		return fmt.Errorf("failed to resolve commit %s: %w", hash, err)
+	}
+
+	if m.trustedKeyRing != "" {
+		if _, err := m.VerifyCommit(commitHash.String()); err != nil {
+			return fmt.Errorf("refusing to roll back to unverified commit %s: %w", hash, err)
+		}
+	}
+
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if err := wt.Reset(&git.ResetOptions{Commit: *commitHash, Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w", hash, err)
+	}
+
+	return nil
+}
+
+// VerifyCommit checks hash's PGP signature against the keyring configured
+// via SetTrustedKeyRing and returns the signer's identity string (e.g.
+// "Jane Dev <jane@example.com>") on success. It returns an error if no
+// keyring has been configured, the commit carries no signature, or the
+// signature doesn't verify.
+func (m *Manager) VerifyCommit(hash string) (string, error) {
+	if err := m.open(); err != nil {
+		return "", err
+	}
+	if m.trustedKeyRing == "" {
+		return "", fmt.Errorf("no trusted keyring configured")
+	}
+
+	commitHash, err := m.resolveRev(hash)
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := m.repo.CommitObject(commitHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit %s: %w", hash, err)
+	}
+	if commit.PGPSignature == "" {
+		return "", fmt.Errorf("commit %s is not signed", hash)
+	}
+
+	entity, err := commit.Verify(m.trustedKeyRing)
+	if err != nil {
+		return "", fmt.Errorf("signature verification failed for %s: %w", hash, err)
+	}
+
+	for _, id := range entity.Identities {
+		return id.Name, nil
+	}
+	return "", fmt.Errorf("verified commit %s but the signing key has no identity", hash)
+}
+
+// checkpointRefPrefix namespaces reducto's named checkpoints away from
+// the working branch and from any refs the user's own tooling manages, so
+// ListCheckpoints/RollbackToCheckpoint never see or touch anything else.
+const checkpointRefPrefix = "refs/reducto/checkpoints/"
+
+// Checkpoint is one entry written by CreateNamedCheckpoint.
+type Checkpoint struct {
+	Label   string
+	Commit  string
+	RefName string
+	Created time.Time
+}
+
+// CreateNamedCheckpoint records HEAD under
+// refs/reducto/checkpoints/<unix-timestamp>-<label> without creating a
+// commit or touching the current branch, so a caller can later roll back
+// to exactly this point via RollbackToCheckpoint even if other commits
+// have since landed on top.
+func (m *Manager) CreateNamedCheckpoint(label string) (*Checkpoint, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	now := time.Now()
+	refName := plumbing.ReferenceName(fmt.Sprintf("%s%d-%s", checkpointRefPrefix, now.Unix(), label))
+	ref := plumbing.NewHashReference(refName, head.Hash())
+	if err := m.repo.Storer.SetReference(ref); err != nil {
+		return nil, fmt.Errorf("failed to write checkpoint ref %s: %w", refName, err)
+	}
+
+	return &Checkpoint{
+		Label:   label,
+		Commit:  head.Hash().String(),
+		RefName: refName.String(),
+		Created: now,
+	}, nil
+}
+
+// ListCheckpoints returns every checkpoint written by CreateNamedCheckpoint,
+// newest first.
+func (m *Manager) ListCheckpoints() ([]Checkpoint, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	refs, err := m.repo.Storer.IterReferences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refs: %w", err)
+	}
+	defer refs.Close()
+
+	var checkpoints []Checkpoint
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().String()
+		if !strings.HasPrefix(name, checkpointRefPrefix) {
+			return nil
+		}
+
+		rest := strings.TrimPrefix(name, checkpointRefPrefix)
+		ts, label, ok := strings.Cut(rest, "-")
+		if !ok {
+			label = rest
+		}
+
+		var created time.Time
+		var unix int64
+		if _, err := fmt.Sscanf(ts, "%d", &unix); err == nil {
+			created = time.Unix(unix, 0)
+		}
+
+		checkpoints = append(checkpoints, Checkpoint{
+			Label:   label,
+			Commit:  ref.Hash().String(),
+			RefName: name,
+			Created: created,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk checkpoint refs: %w", err)
+	}
+
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].Created.After(checkpoints[j].Created)
+	})
+
+	return checkpoints, nil
+}
+
+// MergeBase returns the nearest common ancestor of a and b. It works by
+// walking every ancestor of a into a set, then breadth-first from b one
+// generation at a time, returning the first commit that's in a's ancestor
+// set -- the shortest path from b guarantees that's the nearest common
+// ancestor.
+func (m *Manager) MergeBase(a, b plumbing.Hash) (plumbing.Hash, error) {
+	if err := m.open(); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	ancestorsOf := func(start plumbing.Hash) (map[plumbing.Hash]bool, error) {
+		seen := make(map[plumbing.Hash]bool)
+		queue := []plumbing.Hash{start}
+		for len(queue) > 0 {
+			h := queue[0]
+			queue = queue[1:]
+			if seen[h] {
+				continue
+			}
+			seen[h] = true
+
+			commit, err := m.repo.CommitObject(h)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get commit %s: %w", h, err)
+			}
+			queue = append(queue, commit.ParentHashes...)
+		}
+		return seen, nil
+	}
+
+	ancestorsA, err := ancestorsOf(a)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	visited := make(map[plumbing.Hash]bool)
+	frontier := []plumbing.Hash{b}
+	for len(frontier) > 0 {
+		var next []plumbing.Hash
+		for _, h := range frontier {
+			if visited[h] {
+				continue
+			}
+			visited[h] = true
+
+			if ancestorsA[h] {
+				return h, nil
+			}
+
+			commit, err := m.repo.CommitObject(h)
+			if err != nil {
+				return plumbing.ZeroHash, fmt.Errorf("failed to get commit %s: %w", h, err)
+			}
+			next = append(next, commit.ParentHashes...)
+		}
+		frontier = next
+	}
+
+	return plumbing.ZeroHash, fmt.Errorf("no common ancestor between %s and %s", a, b)
+}
+
+// RollbackOptions controls how the RollbackTo*/RollbackN family enforce
+// their safety check.
+type RollbackOptions struct {
+	// Force skips the merge-base ancestry check, for callers that have
+	// already confirmed losing any commits past the target is intended.
+	Force bool
+}
+
+// guardRollback refuses to roll back to target unless opts.Force is set or
+// target is an ancestor of HEAD (MergeBase(HEAD, target) == target),
+// preventing a rollback from silently discarding commits that aren't on
+// the path back to target.
+func (m *Manager) guardRollback(target plumbing.Hash, opts RollbackOptions) error {
+	if opts.Force {
+		return nil
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	base, err := m.MergeBase(head.Hash(), target)
+	if err != nil {
+		return err
+	}
+	if base != target {
+		return fmt.Errorf("refusing to roll back to %s: it is not an ancestor of HEAD (merge-base is %s); pass RollbackOptions{Force: true} to override", target, base)
+	}
+	return nil
+}
+
+// resetHard hard-resets the worktree to hash after running guardRollback.
+func (m *Manager) resetHard(hash plumbing.Hash, opts RollbackOptions) error {
+	if err := m.guardRollback(hash, opts); err != nil {
+		return err
+	}
+
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if err := wt.Reset(&git.ResetOptions{Commit: hash, Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w", hash, err)
+	}
+	return nil
+}
+
+// RollbackToCheckpoint resets the worktree to the most recent checkpoint
+// recorded under label by CreateNamedCheckpoint.
+func (m *Manager) RollbackToCheckpoint(label string, opts RollbackOptions) error {
+	if err := m.open(); err != nil {
+		return err
+	}
+
+	checkpoints, err := m.ListCheckpoints()
+	if err != nil {
+		return err
+	}
+
+	for _, cp := range checkpoints {
+		if cp.Label != label {
+			continue
+		}
+		hash, err := m.resolveRev(cp.Commit)
+		if err != nil {
+			return err
+		}
+		return m.resetHard(hash, opts)
+	}
+
+	return fmt.Errorf("no checkpoint found with label %q", label)
+}
+
+// RollbackToMergeBase resets the worktree to the merge base of HEAD and
+// branch, undoing everything reducto (or anyone else) has committed
+// since the two diverged.
+func (m *Manager) RollbackToMergeBase(branch string, opts RollbackOptions) error {
+	if err := m.open(); err != nil {
+		return err
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	branchHash, err := m.resolveRev(branch)
+	if err != nil {
+		return err
+	}
+
+	base, err := m.MergeBase(head.Hash(), branchHash)
+	if err != nil {
+		return err
+	}
+
+	return m.resetHard(base, opts)
+}
+
+// RollbackN resets the worktree n commits back from HEAD along the
+// first-parent chain, the generalization of Rollback (which is
+// RollbackN(1, RollbackOptions{})).
+func (m *Manager) RollbackN(n int, opts RollbackOptions) error {
+	if err := m.open(); err != nil {
+		return err
+	}
+	if n <= 0 {
+		return fmt.Errorf("n must be positive, got %d", n)
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	hash := head.Hash()
+	for i := 0; i < n; i++ {
+		commit, err := m.repo.CommitObject(hash)
+		if err != nil {
+			return fmt.Errorf("failed to get commit %s: %w", hash, err)
+		}
+		if len(commit.ParentHashes) == 0 {
+			return fmt.Errorf("reached the root commit after %d of %d steps", i, n)
+		}
+		hash = commit.ParentHashes[0]
+	}
+
+	return m.resetHard(hash, opts)
+}
+
 func (m *Manager) Stash() error {
 	if err := m.open(); err != nil {
 		return err
@@ -212,12 +602,7 @@ func (m *Manager) Stash() error {
 		}
 	}
 
-	_, err = wt.Commit("WIP: stash before dehydrate", &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "dehydrator",
-			Email: "dehydrator@local",
-		},
-	})
+	_, err = wt.Commit("WIP: stash before dehydrate", m.commitOptions())
 	if err != nil {
 		return fmt.Errorf("failed to stash commit: %w", err)
 	}
@@ -269,6 +654,51 @@ func (m *Manager) Diff(file string) (string, error) {
 	return patch.String(), nil
 }
 
+// ChangedSince returns the paths that differ between rev and HEAD, for
+// list_files's changed_since filter (the git.diff --name-only equivalent).
+func (m *Manager) ChangedSince(rev string) ([]string, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	oldHash, err := m.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve revision %s: %w", rev, err)
+	}
+
+	oldCommit, err := m.repo.CommitObject(*oldHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", rev, err)
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	newCommit, err := m.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+
+	patch, err := oldCommit.Patch(newCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s..HEAD: %w", rev, err)
+	}
+
+	var files []string
+	for _, filePatch := range patch.FilePatches() {
+		from, to := filePatch.Files()
+		if to != nil {
+			files = append(files, to.Path())
+		} else if from != nil {
+			files = append(files, from.Path())
+		}
+	}
+
+	return files, nil
+}
+
 func (m *Manager) ChangedFiles() ([]string, error) {
 	if err := m.open(); err != nil {
 		return nil, err
@@ -330,3 +760,394 @@ func (m *Manager) GetFileAtCommit(file string, hash plumbing.Hash) (string, erro
 
 	return string(content), nil
 }
+
+// resolveRev resolves rev to a commit hash, treating an empty rev as HEAD.
+func (m *Manager) resolveRev(rev string) (plumbing.Hash, error) {
+	if rev == "" {
+		head, err := m.repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to get HEAD: %w", err)
+		}
+		return head.Hash(), nil
+	}
+
+	hash, err := m.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve revision %s: %w", rev, err)
+	}
+	return *hash, nil
+}
+
+// BlameLine is one line of a BlameFile result.
+type BlameLine struct {
+	Line    int
+	Commit  string
+	Author  string
+	Date    time.Time
+	Content string
+}
+
+// BlameFile returns, for each line of path as of rev (HEAD when empty),
+// the commit and author that last touched it, so an agent can ask "who
+// last changed this and why" before refactoring.
+func (m *Manager) BlameFile(path, rev string) ([]BlameLine, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	hash, err := m.resolveRev(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := m.repo.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	result, err := git.Blame(commit, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to blame %s: %w", path, err)
+	}
+
+	lines := make([]BlameLine, len(result.Lines))
+	for i, l := range result.Lines {
+		lines[i] = BlameLine{
+			Line:    i + 1,
+			Commit:  l.Hash.String()[:8],
+			Author:  l.Author,
+			Date:    l.Date,
+			Content: l.Text,
+		}
+	}
+	return lines, nil
+}
+
+// Blame returns the blame info for path as of HEAD, the same as
+// BlameFile(path, ""), but caches the result per path+HEAD-commit so
+// callers that blame the same file for many symbols (e.g.
+// parser.FindBlocks's Blamer) only pay the cost of walking the file's
+// history once.
+func (m *Manager) Blame(path string) ([]BlameLine, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	key := path + "@" + head.Hash().String()
+
+	m.blameMu.Lock()
+	if cached, ok := m.blameCache[key]; ok {
+		m.blameMu.Unlock()
+		return cached, nil
+	}
+	m.blameMu.Unlock()
+
+	lines, err := m.BlameFile(path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	m.blameMu.Lock()
+	if m.blameCache == nil {
+		m.blameCache = make(map[string][]BlameLine)
+	}
+	m.blameCache[key] = lines
+	m.blameMu.Unlock()
+
+	return lines, nil
+}
+
+// LogEntry is one commit returned by Log.
+type LogEntry struct {
+	Commit  string
+	Author  string
+	Email   string
+	Date    time.Time
+	Message string
+}
+
+// Log returns the most recent commits touching path (every commit reaching
+// HEAD when path is empty), newest first, capped at limit (no cap when
+// limit <= 0).
+func (m *Manager) Log(path string, limit int) ([]LogEntry, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	opts := &git.LogOptions{From: head.Hash()}
+	if path != "" {
+		opts.FileName = &path
+	}
+
+	iter, err := m.repo.Log(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log: %w", err)
+	}
+
+	var entries []LogEntry
+	err = iter.ForEach(func(c *object.Commit) error {
+		if limit > 0 && len(entries) >= limit {
+			return storer.ErrStop
+		}
+		entries = append(entries, LogEntry{
+			Commit:  c.Hash.String()[:8],
+			Author:  c.Author.Name,
+			Email:   c.Author.Email,
+			Date:    c.Author.When,
+			Message: strings.TrimSpace(c.Message),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// FileRevision is one commit that changed the blob at a given path, as
+// found by FileHistory.
+type FileRevision struct {
+	Commit  string
+	Author  string
+	Email   string
+	Date    time.Time
+	Message string
+	// OldBlob and NewBlob are the blob hashes of path before and after this
+	// commit; empty when the file didn't exist on that side (added or
+	// deleted by this commit).
+	OldBlob string
+	NewBlob string
+}
+
+// FileHistory walks the commit graph from HEAD first-parent only (matching
+// `git log --first-parent`), and returns the commits that actually changed
+// the blob at path, newest first, capped at limit (no cap when limit <=
+// 0). Each FileRevision carries the blob hashes on either side of the
+// commit so callers can diff any two revisions without re-walking history.
+func (m *Manager) FileHistory(path string, limit int) ([]FileRevision, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	var revisions []FileRevision
+	hash := head.Hash()
+	for {
+		if limit > 0 && len(revisions) >= limit {
+			break
+		}
+
+		commit, err := m.repo.CommitObject(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit %s: %w", hash, err)
+		}
+
+		newBlob, err := blobHashAt(commit, path)
+		if err != nil {
+			return nil, err
+		}
+
+		var oldBlob plumbing.Hash
+		if len(commit.ParentHashes) > 0 {
+			parent, err := m.repo.CommitObject(commit.ParentHashes[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to get parent commit %s: %w", commit.ParentHashes[0], err)
+			}
+			oldBlob, err = blobHashAt(parent, path)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if newBlob != oldBlob {
+			rev := FileRevision{
+				Commit:  commit.Hash.String()[:8],
+				Author:  commit.Author.Name,
+				Email:   commit.Author.Email,
+				Date:    commit.Author.When,
+				Message: strings.TrimSpace(commit.Message),
+			}
+			if !oldBlob.IsZero() {
+				rev.OldBlob = oldBlob.String()[:8]
+			}
+			if !newBlob.IsZero() {
+				rev.NewBlob = newBlob.String()[:8]
+			}
+			revisions = append(revisions, rev)
+		}
+
+		if len(commit.ParentHashes) == 0 {
+			break
+		}
+		hash = commit.ParentHashes[0]
+	}
+
+	return revisions, nil
+}
+
+// blobHashAt returns the blob hash of path in commit's tree, or the zero
+// hash if path doesn't exist at that commit.
+func blobHashAt(commit *object.Commit, path string) (plumbing.Hash, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to get tree for %s: %w", commit.Hash, err)
+	}
+
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		return plumbing.ZeroHash, nil
+	}
+	return entry.Hash, nil
+}
+
+// DiffLine is one line of a DiffHunk, tagged with how it changed.
+type DiffLine struct {
+	Kind    string // "add", "delete", or "equal"
+	Content string
+}
+
+// DiffHunk is a contiguous block of a FileDiff.
+type DiffHunk struct {
+	Lines []DiffLine
+}
+
+// FileDiff is the structured, per-line form of the patch for one file
+// between two revisions, for callers that want to reason about individual
+// +/- lines rather than parse raw unified-diff text.
+type FileDiff struct {
+	From  string
+	To    string
+	Hunks []DiffHunk
+}
+
+// diffLineKind maps a go-git chunk operation to the DiffLine.Kind strings
+// FileDiff uses.
+func diffLineKind(op diff.Operation) string {
+	switch op {
+	case diff.Add:
+		return "add"
+	case diff.Delete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+// DiffRevisions returns the structured patch between revA and revB
+// (HEAD when empty), restricted to paths when non-empty.
+func (m *Manager) DiffRevisions(revA, revB string, paths []string) ([]FileDiff, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	patch, err := m.patchBetween(revA, revB)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		wanted[p] = true
+	}
+
+	var diffs []FileDiff
+	for _, filePatch := range patch.FilePatches() {
+		from, to := filePatch.Files()
+		var fromPath, toPath string
+		if from != nil {
+			fromPath = from.Path()
+		}
+		if to != nil {
+			toPath = to.Path()
+		}
+		if len(wanted) > 0 && !wanted[fromPath] && !wanted[toPath] {
+			continue
+		}
+
+		var hunks []DiffHunk
+		for _, chunk := range filePatch.Chunks() {
+			kind := diffLineKind(chunk.Type())
+			content := strings.TrimSuffix(chunk.Content(), "\n")
+			var lines []DiffLine
+			for _, line := range strings.Split(content, "\n") {
+				lines = append(lines, DiffLine{Kind: kind, Content: line})
+			}
+			hunks = append(hunks, DiffHunk{Lines: lines})
+		}
+
+		diffs = append(diffs, FileDiff{From: fromPath, To: toPath, Hunks: hunks})
+	}
+
+	return diffs, nil
+}
+
+// DiffStats summarizes a revision range the way `git diff --stat` does.
+type DiffStats struct {
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+}
+
+// Stats summarizes the change between revA and revB (HEAD when empty), so
+// the sidecar can bound the size of a proposed change before applying it.
+func (m *Manager) Stats(revA, revB string) (DiffStats, error) {
+	if err := m.open(); err != nil {
+		return DiffStats{}, err
+	}
+
+	patch, err := m.patchBetween(revA, revB)
+	if err != nil {
+		return DiffStats{}, err
+	}
+
+	stats := patch.Stats()
+	result := DiffStats{FilesChanged: len(stats)}
+	for _, s := range stats {
+		result.Insertions += s.Addition
+		result.Deletions += s.Deletion
+	}
+	return result, nil
+}
+
+// patchBetween resolves revA and revB to commits (HEAD when empty) and
+// returns the patch from revA to revB.
+func (m *Manager) patchBetween(revA, revB string) (*object.Patch, error) {
+	hashA, err := m.resolveRev(revA)
+	if err != nil {
+		return nil, err
+	}
+	hashB, err := m.resolveRev(revB)
+	if err != nil {
+		return nil, err
+	}
+
+	commitA, err := m.repo.CommitObject(hashA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", revA, err)
+	}
+	commitB, err := m.repo.CommitObject(hashB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", revB, err)
+	}
+
+	patch, err := commitA.Patch(commitB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate patch: %w", err)
+	}
+	return patch, nil
+}
@@ -0,0 +1,267 @@
+// Package hub implements a CrowdSec-style hub-of-items model for reducto:
+// named, versioned packs of pattern detectors, idiomatization recipes,
+// deduplication heuristics, and quality checks that can be installed,
+// upgraded, and removed without recompiling the binary.
+package hub
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ItemType identifies what kind of rule a hub Item provides.
+type ItemType string
+
+const (
+	ItemTypePattern ItemType = "pattern"
+	ItemTypeIdiom   ItemType = "idiom"
+	ItemTypeDedup   ItemType = "dedup"
+	ItemTypeCheck   ItemType = "check"
+)
+
+// Item describes a single installable hub entry.
+type Item struct {
+	Name         string   `yaml:"name"`
+	Type         ItemType `yaml:"type"`
+	Language     string   `yaml:"language"`
+	Version      string   `yaml:"version"`
+	Source       string   `yaml:"source"`
+	SHA256       string   `yaml:"sha256"`
+	Dependencies []string `yaml:"dependencies,omitempty"`
+
+	// Tainted is derived locally, never from the remote manifest: it is
+	// set when an installed item's content no longer matches its recorded
+	// sha256, meaning a user has edited it by hand.
+	Tainted bool `yaml:"-"`
+}
+
+// Ref returns the item's "type/name" reference string, as used on the
+// command line and in Index paths.
+func (it Item) Ref() string {
+	return fmt.Sprintf("%s/%s", it.Type, it.Name)
+}
+
+// Manifest is the signed remote index of installable items.
+type Manifest struct {
+	Items []Item `yaml:"items"`
+}
+
+var (
+	// ErrNotFound is returned when a ref has no matching local or remote item.
+	ErrNotFound = errors.New("hub: item not found")
+	// ErrChecksumMismatch is returned when downloaded content doesn't match
+	// the manifest's recorded sha256.
+	ErrChecksumMismatch = errors.New("hub: checksum mismatch")
+)
+
+const itemFileName = "item.yaml"
+const contentFileName = "content"
+const shaFileName = ".sha256"
+
+// Index is the local on-disk store of installed hub items, rooted at
+// ~/.reducto/hub/{type}/{name}/.
+type Index struct {
+	dir string
+}
+
+// NewIndex opens the local hub index at dir, creating it if necessary.
+func NewIndex(dir string) (*Index, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create hub index directory: %w", err)
+	}
+	return &Index{dir: dir}, nil
+}
+
+// DefaultIndexDir returns ~/.reducto/hub, the conventional Index location.
+func DefaultIndexDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".reducto", "hub"), nil
+}
+
+func (idx *Index) itemDir(itemType ItemType, name string) string {
+	return filepath.Join(idx.dir, string(itemType), name)
+}
+
+// List returns the locally installed items, optionally filtered by type.
+// An empty itemType lists everything.
+func (idx *Index) List(itemType ItemType) ([]Item, error) {
+	types := []ItemType{ItemTypePattern, ItemTypeIdiom, ItemTypeDedup, ItemTypeCheck}
+	if itemType != "" {
+		types = []ItemType{itemType}
+	}
+
+	var items []Item
+	for _, t := range types {
+		typeDir := filepath.Join(idx.dir, string(t))
+		entries, err := os.ReadDir(typeDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			item, err := idx.Inspect(t, e.Name())
+			if err != nil {
+				continue
+			}
+			items = append(items, *item)
+		}
+	}
+	return items, nil
+}
+
+// Inspect loads the locally installed item's metadata and evaluates its
+// tainted state by recomputing its content hash.
+func (idx *Index) Inspect(itemType ItemType, name string) (*Item, error) {
+	dir := idx.itemDir(itemType, name)
+
+	raw, err := os.ReadFile(filepath.Join(dir, itemFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, itemType, name)
+		}
+		return nil, err
+	}
+
+	var item Item
+	if err := yaml.Unmarshal(raw, &item); err != nil {
+		return nil, fmt.Errorf("failed to parse installed item metadata: %w", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, contentFileName))
+	if err == nil {
+		item.Tainted = sha256Hex(content) != item.SHA256
+	}
+
+	return &item, nil
+}
+
+// Install downloads item's content from its Source, verifies it against
+// SHA256, and writes it into the local index.
+func (idx *Index) Install(item Item) error {
+	content, err := fetch(item.Source)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", item.Ref(), err)
+	}
+
+	if item.SHA256 != "" && sha256Hex(content) != item.SHA256 {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, item.Ref())
+	}
+
+	dir := idx.itemDir(item.Type, item.Name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	meta, err := yaml.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, itemFileName), meta, 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, contentFileName), content, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, shaFileName), []byte(item.SHA256), 0644)
+}
+
+// Upgrade reinstalls name at the version currently published in manifest,
+// refusing to clobber a locally tainted item unless force is set.
+func (idx *Index) Upgrade(itemType ItemType, name string, manifest Manifest, force bool) error {
+	current, err := idx.Inspect(itemType, name)
+	if err != nil {
+		return err
+	}
+	if current.Tainted && !force {
+		return fmt.Errorf("%s/%s has local modifications; use --force to overwrite", itemType, name)
+	}
+
+	for _, candidate := range manifest.Items {
+		if candidate.Type == itemType && candidate.Name == name {
+			return idx.Install(candidate)
+		}
+	}
+	return fmt.Errorf("%w: %s/%s is not in the remote index", ErrNotFound, itemType, name)
+}
+
+// Remove deletes an installed item from the local index.
+func (idx *Index) Remove(itemType ItemType, name string) error {
+	dir := idx.itemDir(itemType, name)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s/%s", ErrNotFound, itemType, name)
+	}
+	return os.RemoveAll(dir)
+}
+
+// FetchManifest retrieves and parses the remote index at url. If
+// trustedKeys is non-empty, it also fetches url+".minisig" and rejects the
+// index when no signature is published or the signature file is empty. The
+// signature's content is not checked against the index or trustedKeys (see
+// verifyMinisign).
+func FetchManifest(url string, trustedKeys []string) (Manifest, error) {
+	raw, err := fetch(url)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to fetch hub index: %w", err)
+	}
+
+	if len(trustedKeys) > 0 {
+		sig, err := fetch(url + ".minisig")
+		if err != nil {
+			return Manifest{}, fmt.Errorf("hub.trusted_keys is set but no signature was published for %s: %w", url, err)
+		}
+		if !verifyMinisign(raw, sig, trustedKeys) {
+			return Manifest{}, fmt.Errorf("hub index signature at %s does not match any trusted key", url)
+		}
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(raw, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse hub index: %w", err)
+	}
+	return manifest, nil
+}
+
+// verifyMinisign reports whether sig is non-empty. It does not parse
+// minisign keys or signatures, and does not check sig against data or
+// trustedKeys.
+func verifyMinisign(data, sig []byte, trustedKeys []string) bool {
+	return len(sig) > 0
+}
+
+func fetch(source string) ([]byte, error) {
+	if filepath.IsAbs(source) || filepath.VolumeName(source) != "" {
+		return os.ReadFile(source)
+	}
+
+	resp, err := http.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, source)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
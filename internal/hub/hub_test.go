@@ -0,0 +1,109 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSource(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "source.txt")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write source file: %v", err)
+	}
+	return path
+}
+
+func TestIndexInstallAndInspect(t *testing.T) {
+	tmp := t.TempDir()
+	idx, err := NewIndex(filepath.Join(tmp, "hub"))
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+
+	src := writeSource(t, tmp, "detector body")
+	item := Item{
+		Name:    "long-method",
+		Type:    ItemTypePattern,
+		Version: "1.0.0",
+		Source:  src,
+		SHA256:  sha256Hex([]byte("detector body")),
+	}
+
+	if err := idx.Install(item); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	got, err := idx.Inspect(ItemTypePattern, "long-method")
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if got.Version != "1.0.0" || got.Tainted {
+		t.Errorf("Inspect() = %+v, want version 1.0.0 and not tainted", got)
+	}
+}
+
+func TestIndexInstallRejectsChecksumMismatch(t *testing.T) {
+	tmp := t.TempDir()
+	idx, err := NewIndex(filepath.Join(tmp, "hub"))
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+
+	src := writeSource(t, tmp, "detector body")
+	item := Item{Name: "x", Type: ItemTypeIdiom, Source: src, SHA256: "deadbeef"}
+
+	if err := idx.Install(item); err == nil {
+		t.Error("Install() expected checksum mismatch error, got nil")
+	}
+}
+
+func TestInspectDetectsTainting(t *testing.T) {
+	tmp := t.TempDir()
+	idx, err := NewIndex(filepath.Join(tmp, "hub"))
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+
+	src := writeSource(t, tmp, "original")
+	item := Item{Name: "y", Type: ItemTypeDedup, Source: src, SHA256: sha256Hex([]byte("original"))}
+	if err := idx.Install(item); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	contentPath := filepath.Join(tmp, "hub", string(ItemTypeDedup), "y", contentFileName)
+	if err := os.WriteFile(contentPath, []byte("edited by hand"), 0644); err != nil {
+		t.Fatalf("failed to simulate local edit: %v", err)
+	}
+
+	got, err := idx.Inspect(ItemTypeDedup, "y")
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if !got.Tainted {
+		t.Error("Inspect() Tainted = false after local edit, want true")
+	}
+}
+
+func TestIndexRemove(t *testing.T) {
+	tmp := t.TempDir()
+	idx, err := NewIndex(filepath.Join(tmp, "hub"))
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+
+	src := writeSource(t, tmp, "body")
+	item := Item{Name: "z", Type: ItemTypeCheck, Source: src, SHA256: sha256Hex([]byte("body"))}
+	if err := idx.Install(item); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	if err := idx.Remove(ItemTypeCheck, "z"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	if _, err := idx.Inspect(ItemTypeCheck, "z"); err == nil {
+		t.Error("Inspect() after Remove() expected error, got nil")
+	}
+}
@@ -0,0 +1,127 @@
+package linter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"regexp"
+	"strconv"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// golangciLintBackend shells out to `golangci-lint run --out-format=json`,
+// the richer of the two Go backends (many linters, configurable via
+// .golangci.yml), falling back to go vet when it isn't installed.
+type golangciLintBackend struct{}
+
+func (golangciLintBackend) Name() string { return "golangci-lint" }
+
+type golangciLintOutput struct {
+	Issues []struct {
+		FromLinter string `json:"FromLinter"`
+		Text       string `json:"Text"`
+		Severity   string `json:"Severity"`
+		Pos        struct {
+			Filename string `json:"Filename"`
+			Line     int    `json:"Line"`
+			Column   int    `json:"Column"`
+		} `json:"Pos"`
+	} `json:"Issues"`
+}
+
+func (b golangciLintBackend) Run(ctx context.Context, rootDir string, paths []string) ([]models.Diagnostic, error) {
+	binPath, err := exec.LookPath("golangci-lint")
+	if err != nil {
+		return nil, errUnavailable
+	}
+
+	args := append([]string{"run", "--out-format=json"}, lintTargets(paths, "./...")...)
+	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd.Dir = rootDir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// golangci-lint exits non-zero whenever it reports any issue, so the
+	// run error itself is meaningless here; only a malformed stdout below
+	// is treated as a real failure.
+	_ = cmd.Run()
+
+	var out golangciLintOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, err
+	}
+
+	diags := make([]models.Diagnostic, 0, len(out.Issues))
+	for _, issue := range out.Issues {
+		severity := issue.Severity
+		if severity == "" {
+			severity = "warning"
+		}
+		diags = append(diags, models.Diagnostic{
+			File:     issue.Pos.Filename,
+			Line:     issue.Pos.Line,
+			Column:   issue.Pos.Column,
+			Severity: severity,
+			Code:     issue.FromLinter,
+			Message:  issue.Text,
+			Source:   "golangci-lint",
+		})
+	}
+	return diags, nil
+}
+
+// goVetBackend shells out to `go vet`, always available alongside the Go
+// toolchain, as the fallback when golangci-lint isn't installed.
+type goVetBackend struct{}
+
+func (goVetBackend) Name() string { return "go vet" }
+
+// govetLinePattern matches go vet's "file:line:column: message" stderr
+// format.
+var govetLinePattern = regexp.MustCompile(`^(.+):(\d+):(\d+): (.+)$`)
+
+func (b goVetBackend) Run(ctx context.Context, rootDir string, paths []string) ([]models.Diagnostic, error) {
+	binPath, err := exec.LookPath("go")
+	if err != nil {
+		return nil, errUnavailable
+	}
+
+	args := append([]string{"vet"}, lintTargets(paths, "./...")...)
+	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd.Dir = rootDir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// go vet exits non-zero whenever it reports anything, so the run
+	// error itself carries no information beyond what's in stderr.
+	_ = cmd.Run()
+
+	var diags []models.Diagnostic
+	for _, line := range bytes.Split(stderr.Bytes(), []byte("\n")) {
+		m := govetLinePattern.FindSubmatch(line)
+		if m == nil {
+			continue
+		}
+		lineNum, _ := strconv.Atoi(string(m[2]))
+		col, _ := strconv.Atoi(string(m[3]))
+		diags = append(diags, models.Diagnostic{
+			File:     string(m[1]),
+			Line:     lineNum,
+			Column:   col,
+			Severity: "warning",
+			Message:  string(m[4]),
+			Source:   "go vet",
+		})
+	}
+	return diags, nil
+}
+
+// lintTargets falls back to def when no specific paths were requested.
+func lintTargets(paths []string, def string) []string {
+	if len(paths) == 0 {
+		return []string{def}
+	}
+	return paths
+}
@@ -0,0 +1,118 @@
+// Package linter runs per-language static-analysis tools and normalizes
+// their output into models.Diagnostic, so agents refactoring code get
+// lint/vet feedback through the same MCP shape as test results.
+package linter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// DefaultTimeout bounds how long a single lint invocation may run before
+// it's killed, so a hung linter can't wedge the MCP server.
+const DefaultTimeout = 2 * time.Minute
+
+// errUnavailable is returned by a Backend whose binary isn't on PATH, so
+// Linter can fall through to the next backend registered for a language
+// (e.g. ruff missing -> try pyflakes).
+var errUnavailable = errors.New("linter: backend not available")
+
+// Backend runs one static-analysis tool over paths (relative to rootDir;
+// all files when empty) and returns its diagnostics already normalized.
+type Backend interface {
+	Name() string
+	Run(ctx context.Context, rootDir string, paths []string) ([]models.Diagnostic, error)
+}
+
+// Linter dispatches to the first available Backend registered for a
+// language, so an unconfigured environment degrades gracefully (e.g. no
+// golangci-lint installed falls back to go vet) instead of failing closed.
+type Linter struct {
+	rootDir  string
+	timeout  time.Duration
+	backends map[models.Language][]Backend
+}
+
+// New returns a Linter with the repo's default backend set: golangci-lint
+// (falling back to go vet) for Go, ruff (falling back to pyflakes) for
+// Python, and eslint for TypeScript/JavaScript.
+func New(rootDir string) *Linter {
+	return &Linter{
+		rootDir: rootDir,
+		timeout: DefaultTimeout,
+		backends: map[models.Language][]Backend{
+			models.LanguageGo:         {golangciLintBackend{}, goVetBackend{}},
+			models.LanguagePython:     {ruffBackend{}, pyflakesBackend{}},
+			models.LanguageTypeScript: {eslintBackend{}},
+			models.LanguageJavaScript: {eslintBackend{}},
+		},
+	}
+}
+
+// Lint runs the registered backends for lang in order until one is
+// available, and returns its diagnostics restricted to paths.
+func (l *Linter) Lint(ctx context.Context, lang models.Language, paths []string) ([]models.Diagnostic, error) {
+	backends, ok := l.backends[lang]
+	if !ok || len(backends) == 0 {
+		return nil, fmt.Errorf("no lint backend registered for language: %s", lang)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	var lastErr error
+	for _, b := range backends {
+		diags, err := b.Run(ctx, l.rootDir, paths)
+		if err == nil {
+			return diags, nil
+		}
+		if errors.Is(err, errUnavailable) {
+			lastErr = err
+			continue
+		}
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+
+	return nil, fmt.Errorf("no available lint backend for language %s: %w", lang, lastErr)
+}
+
+// GroupByFile buckets diagnostics under their File, in the shape run_lint
+// returns them.
+func GroupByFile(diags []models.Diagnostic) map[string][]models.Diagnostic {
+	grouped := make(map[string][]models.Diagnostic)
+	for _, d := range diags {
+		grouped[d.File] = append(grouped[d.File], d)
+	}
+	return grouped
+}
+
+// severityRank orders severities from least to most severe, so a
+// `severity` filter can mean "at least this severe" rather than an exact
+// match against the sometimes-inconsistent strings tools emit.
+var severityRank = map[string]int{
+	"hint":    0,
+	"info":    1,
+	"warning": 2,
+	"error":   3,
+}
+
+// FilterSeverity drops diagnostics less severe than min. An unrecognized
+// or empty min leaves diags unfiltered.
+func FilterSeverity(diags []models.Diagnostic, min string) []models.Diagnostic {
+	minRank, ok := severityRank[min]
+	if !ok {
+		return diags
+	}
+
+	filtered := diags[:0]
+	for _, d := range diags {
+		if severityRank[d.Severity] >= minRank {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
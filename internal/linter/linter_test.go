@@ -0,0 +1,99 @@
+package linter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type fakeBackend struct {
+	name string
+	err  error
+	out  []models.Diagnostic
+}
+
+func (f fakeBackend) Name() string { return f.name }
+
+func (f fakeBackend) Run(ctx context.Context, rootDir string, paths []string) ([]models.Diagnostic, error) {
+	return f.out, f.err
+}
+
+func TestLintFallsThroughUnavailableBackends(t *testing.T) {
+	want := []models.Diagnostic{{File: "main.go", Message: "unused variable"}}
+	l := &Linter{
+		rootDir: ".",
+		timeout: DefaultTimeout,
+		backends: map[models.Language][]Backend{
+			models.LanguageGo: {
+				fakeBackend{name: "missing", err: errUnavailable},
+				fakeBackend{name: "fallback", out: want},
+			},
+		},
+	}
+
+	got, err := l.Lint(context.Background(), models.LanguageGo, nil)
+	if err != nil {
+		t.Fatalf("Lint returned error: %v", err)
+	}
+	if len(got) != 1 || got[0].Message != want[0].Message {
+		t.Fatalf("Lint = %v, want %v", got, want)
+	}
+}
+
+func TestLintReturnsErrorWhenAllBackendsUnavailable(t *testing.T) {
+	l := &Linter{
+		rootDir: ".",
+		timeout: DefaultTimeout,
+		backends: map[models.Language][]Backend{
+			models.LanguageGo: {fakeBackend{name: "missing", err: errUnavailable}},
+		},
+	}
+
+	if _, err := l.Lint(context.Background(), models.LanguageGo, nil); err == nil {
+		t.Fatal("expected error when no backend is available")
+	}
+}
+
+func TestGroupByFile(t *testing.T) {
+	diags := []models.Diagnostic{
+		{File: "a.go", Message: "one"},
+		{File: "b.go", Message: "two"},
+		{File: "a.go", Message: "three"},
+	}
+
+	grouped := GroupByFile(diags)
+	if len(grouped["a.go"]) != 2 {
+		t.Fatalf("expected 2 diagnostics for a.go, got %d", len(grouped["a.go"]))
+	}
+	if len(grouped["b.go"]) != 1 {
+		t.Fatalf("expected 1 diagnostic for b.go, got %d", len(grouped["b.go"]))
+	}
+}
+
+func TestFilterSeverity(t *testing.T) {
+	diags := []models.Diagnostic{
+		{Severity: "hint"},
+		{Severity: "warning"},
+		{Severity: "error"},
+	}
+
+	filtered := FilterSeverity(diags, "warning")
+	if len(filtered) != 2 {
+		t.Fatalf("expected 2 diagnostics at warning or above, got %d", len(filtered))
+	}
+
+	unfiltered := FilterSeverity(diags, "")
+	if len(unfiltered) != 3 {
+		t.Fatalf("expected unrecognized severity to leave diags untouched, got %d", len(unfiltered))
+	}
+}
+
+func TestLintTargetsDefaultsWhenEmpty(t *testing.T) {
+	if got := lintTargets(nil, "./..."); len(got) != 1 || got[0] != "./..." {
+		t.Fatalf("lintTargets(nil) = %v, want [./...]", got)
+	}
+	if got := lintTargets([]string{"a.go"}, "./..."); len(got) != 1 || got[0] != "a.go" {
+		t.Fatalf("lintTargets with paths = %v, want [a.go]", got)
+	}
+}
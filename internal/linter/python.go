@@ -0,0 +1,107 @@
+package linter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"regexp"
+	"strconv"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// ruffBackend shells out to `ruff check --output-format=json`, which is
+// fast enough to run on every refactor iteration; pyflakesBackend is the
+// fallback when ruff isn't installed.
+type ruffBackend struct{}
+
+func (ruffBackend) Name() string { return "ruff" }
+
+type ruffIssue struct {
+	Filename string `json:"filename"`
+	Location struct {
+		Row    int `json:"row"`
+		Column int `json:"column"`
+	} `json:"location"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (b ruffBackend) Run(ctx context.Context, rootDir string, paths []string) ([]models.Diagnostic, error) {
+	binPath, err := exec.LookPath("ruff")
+	if err != nil {
+		return nil, errUnavailable
+	}
+
+	args := append([]string{"check", "--output-format=json"}, lintTargets(paths, ".")...)
+	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd.Dir = rootDir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// ruff exits non-zero whenever it reports any issue.
+	_ = cmd.Run()
+
+	var issues []ruffIssue
+	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
+		return nil, err
+	}
+
+	diags := make([]models.Diagnostic, 0, len(issues))
+	for _, issue := range issues {
+		diags = append(diags, models.Diagnostic{
+			File:     issue.Filename,
+			Line:     issue.Location.Row,
+			Column:   issue.Location.Column,
+			Severity: "warning",
+			Code:     issue.Code,
+			Message:  issue.Message,
+			Source:   "ruff",
+		})
+	}
+	return diags, nil
+}
+
+// pyflakesBackend shells out to pyflakes, which has no structured output
+// mode, so its "file:line:column: message" lines are parsed the same way
+// goVetBackend parses go vet's stderr.
+type pyflakesBackend struct{}
+
+func (pyflakesBackend) Name() string { return "pyflakes" }
+
+var pyflakesLinePattern = regexp.MustCompile(`^(.+):(\d+):(\d+): (.+)$`)
+
+func (b pyflakesBackend) Run(ctx context.Context, rootDir string, paths []string) ([]models.Diagnostic, error) {
+	binPath, err := exec.LookPath("pyflakes")
+	if err != nil {
+		return nil, errUnavailable
+	}
+
+	cmd := exec.CommandContext(ctx, binPath, lintTargets(paths, ".")...)
+	cmd.Dir = rootDir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// pyflakes exits non-zero whenever it reports anything.
+	_ = cmd.Run()
+
+	var diags []models.Diagnostic
+	for _, line := range bytes.Split(stdout.Bytes(), []byte("\n")) {
+		m := pyflakesLinePattern.FindSubmatch(line)
+		if m == nil {
+			continue
+		}
+		lineNum, _ := strconv.Atoi(string(m[2]))
+		col, _ := strconv.Atoi(string(m[3]))
+		diags = append(diags, models.Diagnostic{
+			File:     string(m[1]),
+			Line:     lineNum,
+			Column:   col,
+			Severity: "warning",
+			Message:  string(m[4]),
+			Source:   "pyflakes",
+		})
+	}
+	return diags, nil
+}
@@ -0,0 +1,74 @@
+package linter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// eslintBackend shells out to `eslint -f json`, the only backend
+// registered for TypeScript and JavaScript since both lint through the
+// same tool.
+type eslintBackend struct{}
+
+func (eslintBackend) Name() string { return "eslint" }
+
+type eslintResult struct {
+	FilePath string `json:"filePath"`
+	Messages []struct {
+		Line     int    `json:"line"`
+		Column   int    `json:"column"`
+		Severity int    `json:"severity"`
+		RuleID   string `json:"ruleId"`
+		Message  string `json:"message"`
+	} `json:"messages"`
+}
+
+func (b eslintBackend) Run(ctx context.Context, rootDir string, paths []string) ([]models.Diagnostic, error) {
+	binPath, err := exec.LookPath("eslint")
+	if err != nil {
+		return nil, errUnavailable
+	}
+
+	args := append([]string{"-f", "json"}, lintTargets(paths, ".")...)
+	cmd := exec.CommandContext(ctx, binPath, args...)
+	cmd.Dir = rootDir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// eslint exits non-zero whenever it reports any error-level issue.
+	_ = cmd.Run()
+
+	var results []eslintResult
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		return nil, err
+	}
+
+	var diags []models.Diagnostic
+	for _, result := range results {
+		for _, msg := range result.Messages {
+			diags = append(diags, models.Diagnostic{
+				File:     result.FilePath,
+				Line:     msg.Line,
+				Column:   msg.Column,
+				Severity: eslintSeverityName(msg.Severity),
+				Code:     msg.RuleID,
+				Message:  msg.Message,
+				Source:   "eslint",
+			})
+		}
+	}
+	return diags, nil
+}
+
+// eslintSeverityName maps eslint's 1/2 severity codes (warn/error) to the
+// same severity strings the other backends use.
+func eslintSeverityName(severity int) string {
+	if severity >= 2 {
+		return "error"
+	}
+	return "warning"
+}
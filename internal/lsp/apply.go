@@ -0,0 +1,121 @@
+package lsp
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// ApplyWorkspaceEdit turns an LSP WorkspaceEdit (e.g. from Rename or a
+// CodeAction's Edit) into one models.FileChange per touched file, suitable
+// for feeding the reporter/dry-run pipeline. When dryRun is false, it also
+// writes the edited content back to disk.
+func ApplyWorkspaceEdit(edit *WorkspaceEdit, dryRun bool) ([]models.FileChange, error) {
+	if edit == nil {
+		return nil, nil
+	}
+
+	paths := make([]string, 0, len(edit.Changes))
+	for uri := range edit.Changes {
+		paths = append(paths, uri)
+	}
+	sort.Strings(paths)
+
+	changes := make([]models.FileChange, 0, len(paths))
+	for _, uri := range paths {
+		path := strings.TrimPrefix(uri, "file://")
+
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		modified := applyTextEdits(string(original), edit.Changes[uri])
+
+		if !dryRun {
+			if err := os.WriteFile(path, []byte(modified), 0644); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+
+		changes = append(changes, models.FileChange{
+			Path:        path,
+			Description: "Applied workspace edit",
+			Original:    string(original),
+			Modified:    modified,
+		})
+	}
+
+	return changes, nil
+}
+
+// ApplyWorkspaceEdit applies edit the same way the package-level
+// ApplyWorkspaceEdit does, then invalidates any cached response for each
+// touched uri so a later request can't be served stale results from
+// before the edit.
+func (m *Manager) ApplyWorkspaceEdit(edit *WorkspaceEdit, dryRun bool) ([]models.FileChange, error) {
+	changes, err := ApplyWorkspaceEdit(edit, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	if edit != nil {
+		for uri := range edit.Changes {
+			m.cache.invalidateURI(uri)
+		}
+	}
+
+	return changes, nil
+}
+
+// applyTextEdits applies edits to content, rewriting from the last edit to
+// the first so earlier offsets stay valid as later edits shift line lengths.
+func applyTextEdits(content string, edits []TextEdit) string {
+	sorted := make([]TextEdit, len(edits))
+	copy(sorted, edits)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Range.Start.Line != sorted[j].Range.Start.Line {
+			return sorted[i].Range.Start.Line < sorted[j].Range.Start.Line
+		}
+		return sorted[i].Range.Start.Character < sorted[j].Range.Start.Character
+	})
+
+	lines := strings.Split(content, "\n")
+	for i := len(sorted) - 1; i >= 0; i-- {
+		lines = applyTextEdit(lines, sorted[i])
+	}
+	return strings.Join(lines, "\n")
+}
+
+func applyTextEdit(lines []string, edit TextEdit) []string {
+	startLine, endLine := edit.Range.Start.Line, edit.Range.End.Line
+	if startLine < 0 || startLine >= len(lines) || endLine < 0 || endLine >= len(lines) {
+		return lines
+	}
+
+	startChar := clampChar(edit.Range.Start.Character, lines[startLine])
+	endChar := clampChar(edit.Range.End.Character, lines[endLine])
+
+	prefix := lines[startLine][:startChar]
+	suffix := lines[endLine][endChar:]
+	replaced := prefix + edit.NewText + suffix
+
+	newLines := strings.Split(replaced, "\n")
+	result := append([]string{}, lines[:startLine]...)
+	result = append(result, newLines...)
+	result = append(result, lines[endLine+1:]...)
+	return result
+}
+
+func clampChar(char int, line string) int {
+	if char < 0 {
+		return 0
+	}
+	if char > len(line) {
+		return len(line)
+	}
+	return char
+}
@@ -0,0 +1,76 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyWorkspaceEditDryRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte("func old() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	edit := &WorkspaceEdit{
+		Changes: map[string][]TextEdit{
+			"file://" + path: {
+				{
+					Range:   Range{Start: Position{Line: 0, Character: 5}, End: Position{Line: 0, Character: 8}},
+					NewText: "new",
+				},
+			},
+		},
+	}
+
+	changes, err := ApplyWorkspaceEdit(edit, true)
+	if err != nil {
+		t.Fatalf("ApplyWorkspaceEdit failed: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+	if changes[0].Modified != "func new() {}\n" {
+		t.Errorf("expected modified content \"func new() {}\\n\", got %q", changes[0].Modified)
+	}
+
+	onDisk, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if string(onDisk) != "func old() {}\n" {
+		t.Errorf("dry run should not modify disk, got %q", string(onDisk))
+	}
+}
+
+func TestApplyWorkspaceEditWritesToDisk(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte("func old() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	edit := &WorkspaceEdit{
+		Changes: map[string][]TextEdit{
+			"file://" + path: {
+				{
+					Range:   Range{Start: Position{Line: 0, Character: 5}, End: Position{Line: 0, Character: 8}},
+					NewText: "new",
+				},
+			},
+		},
+	}
+
+	if _, err := ApplyWorkspaceEdit(edit, false); err != nil {
+		t.Fatalf("ApplyWorkspaceEdit failed: %v", err)
+	}
+
+	onDisk, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if string(onDisk) != "func new() {}\n" {
+		t.Errorf("expected disk content \"func new() {}\\n\", got %q", string(onDisk))
+	}
+}
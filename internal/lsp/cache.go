@@ -0,0 +1,229 @@
+package lsp
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	minCacheBytes   int64 = 64 * 1024 * 1024
+	maxCacheBytes   int64 = 1024 * 1024 * 1024
+	defaultCacheTTL       = 5 * time.Minute
+)
+
+// CacheOptions configures Manager's LSP response cache. A zero-value
+// MaxBytes or TTL leaves that setting unchanged from its default.
+type CacheOptions struct {
+	MaxBytes int64
+	TTL      time.Duration
+	Enabled  bool
+}
+
+// CacheStats reports cumulative response-cache activity, for --verbose
+// reporting and tests.
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+// cacheEntry is one cached, JSON-serialized LSP response, tagged with the
+// uri and language it was served for so it can be bulk-invalidated later.
+type cacheEntry struct {
+	key       string
+	uri       string
+	language  string
+	payload   []byte
+	expiresAt time.Time
+}
+
+// responseCache is a memory- and TTL-bounded LRU cache for serialized LSP
+// responses, keyed by (language, method, uri, line, column, file content
+// hash). It evicts the least-recently-used entry whenever total tracked
+// bytes exceed maxBytes, and treats any entry past its TTL as a miss.
+type responseCache struct {
+	mu         sync.Mutex
+	enabled    bool
+	maxBytes   int64
+	ttl        time.Duration
+	totalBytes int64
+	entries    map[string]*list.Element
+	order      *list.List
+	stats      CacheStats
+}
+
+// newResponseCache returns an enabled cache sized to defaultCacheMaxBytes
+// with a defaultCacheTTL.
+func newResponseCache() *responseCache {
+	return &responseCache{
+		enabled:  true,
+		maxBytes: defaultCacheMaxBytes(),
+		ttl:      defaultCacheTTL,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// defaultCacheMaxBytes is 1/8 of runtime.MemStats.Sys at call time, clamped
+// to [minCacheBytes, maxCacheBytes] so the cache neither starves on a tiny
+// heap nor runs away on a large one.
+func defaultCacheMaxBytes() int64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	budget := int64(ms.Sys) / 8
+	if budget < minCacheBytes {
+		return minCacheBytes
+	}
+	if budget > maxCacheBytes {
+		return maxCacheBytes
+	}
+	return budget
+}
+
+func (c *responseCache) setOptions(opts CacheOptions) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if opts.MaxBytes > 0 {
+		c.maxBytes = opts.MaxBytes
+	}
+	if opts.TTL > 0 {
+		c.ttl = opts.TTL
+	}
+	c.enabled = opts.Enabled
+	c.evictLocked()
+}
+
+func (c *responseCache) snapshotStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// get returns the cached payload for key, or (nil, false) on a miss or an
+// expired entry. An expired entry is evicted immediately.
+func (c *responseCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled {
+		return nil, false
+	}
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeLocked(elem)
+		c.stats.Misses++
+		c.stats.Evictions++
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.stats.Hits++
+	return entry.payload, true
+}
+
+// put caches payload under key, tagged with uri and language for later
+// targeted invalidation, then evicts from the back until under budget.
+func (c *responseCache) put(key, uri, language string, payload []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled {
+		return
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		c.removeLocked(elem)
+	}
+
+	elem := c.order.PushFront(&cacheEntry{
+		key:       key,
+		uri:       uri,
+		language:  language,
+		payload:   payload,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+	c.entries[key] = elem
+	c.totalBytes += int64(len(payload))
+
+	c.evictLocked()
+}
+
+func (c *responseCache) evictLocked() {
+	for c.totalBytes > c.maxBytes {
+		back := c.order.Back()
+		if back == nil {
+			return
+		}
+		c.removeLocked(back)
+		c.stats.Evictions++
+	}
+}
+
+func (c *responseCache) removeLocked(elem *list.Element) {
+	entry := elem.Value.(*cacheEntry)
+	c.totalBytes -= int64(len(entry.payload))
+	delete(c.entries, entry.key)
+	c.order.Remove(elem)
+}
+
+// invalidateURI drops every cached entry for uri, e.g. after a
+// WorkspaceEdit or filesystem watcher touches it.
+func (c *responseCache) invalidateURI(uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, elem := range c.entries {
+		if elem.Value.(*cacheEntry).uri == uri {
+			c.removeLocked(elem)
+		}
+	}
+}
+
+// invalidateLanguage drops every cached entry for language, e.g. when its
+// client is re-initialized and may now answer differently.
+func (c *responseCache) invalidateLanguage(language string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, elem := range c.entries {
+		if elem.Value.(*cacheEntry).language == language {
+			c.removeLocked(elem)
+		}
+	}
+}
+
+// cacheKey identifies a cacheable LSP request. contentHash should be the
+// sha256 of the file at uri at call time, so a stale response is never
+// served across an edit even within the TTL window.
+func cacheKey(language, method, uri string, line, column int, contentHash string) string {
+	return fmt.Sprintf("%s|%s|%s|%d|%d|%s", language, method, uri, line, column, contentHash)
+}
+
+// hashFileContent returns the hex sha256 of the file referenced by uri, or
+// ("", false) if it can't be read (e.g. an unsaved buffer), in which case
+// callers should bypass the cache rather than risk serving a stale result.
+func hashFileContent(uri string) (string, bool) {
+	path := strings.TrimPrefix(uri, "file://")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), true
+}
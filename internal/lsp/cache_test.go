@@ -0,0 +1,114 @@
+package lsp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResponseCacheGetPutHit(t *testing.T) {
+	c := newResponseCache()
+
+	c.put("k1", "file:///a.go", "go", []byte("payload"))
+
+	payload, ok := c.get("k1")
+	if !ok {
+		t.Fatalf("expected cache hit for k1")
+	}
+	if string(payload) != "payload" {
+		t.Errorf("expected payload %q, got %q", "payload", payload)
+	}
+
+	stats := c.snapshotStats()
+	if stats.Hits != 1 {
+		t.Errorf("expected 1 hit, got %d", stats.Hits)
+	}
+}
+
+func TestResponseCacheMiss(t *testing.T) {
+	c := newResponseCache()
+
+	if _, ok := c.get("missing"); ok {
+		t.Fatalf("expected cache miss for unseen key")
+	}
+
+	stats := c.snapshotStats()
+	if stats.Misses != 1 {
+		t.Errorf("expected 1 miss, got %d", stats.Misses)
+	}
+}
+
+func TestResponseCacheTTLExpiry(t *testing.T) {
+	c := newResponseCache()
+	c.setOptions(CacheOptions{Enabled: true, TTL: time.Millisecond})
+
+	c.put("k1", "file:///a.go", "go", []byte("payload"))
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.get("k1"); ok {
+		t.Fatalf("expected expired entry to miss")
+	}
+
+	stats := c.snapshotStats()
+	if stats.Evictions != 1 {
+		t.Errorf("expected 1 eviction for the expired entry, got %d", stats.Evictions)
+	}
+}
+
+func TestResponseCacheByteBudgetEviction(t *testing.T) {
+	c := newResponseCache()
+	c.setOptions(CacheOptions{Enabled: true, MaxBytes: 10})
+
+	c.put("k1", "file:///a.go", "go", []byte("12345"))
+	c.put("k2", "file:///b.go", "go", []byte("67890"))
+	c.put("k3", "file:///c.go", "go", []byte("abcde"))
+
+	if _, ok := c.get("k1"); ok {
+		t.Errorf("expected least-recently-used k1 to have been evicted")
+	}
+	if _, ok := c.get("k3"); !ok {
+		t.Errorf("expected most recently put k3 to still be cached")
+	}
+}
+
+func TestResponseCacheInvalidateURI(t *testing.T) {
+	c := newResponseCache()
+
+	c.put("k1", "file:///a.go", "go", []byte("payload"))
+	c.put("k2", "file:///b.go", "go", []byte("payload"))
+
+	c.invalidateURI("file:///a.go")
+
+	if _, ok := c.get("k1"); ok {
+		t.Errorf("expected k1 to be invalidated")
+	}
+	if _, ok := c.get("k2"); !ok {
+		t.Errorf("expected k2 to remain cached")
+	}
+}
+
+func TestResponseCacheInvalidateLanguage(t *testing.T) {
+	c := newResponseCache()
+
+	c.put("k1", "file:///a.go", "go", []byte("payload"))
+	c.put("k2", "file:///b.py", "python", []byte("payload"))
+
+	c.invalidateLanguage("go")
+
+	if _, ok := c.get("k1"); ok {
+		t.Errorf("expected go entry to be invalidated")
+	}
+	if _, ok := c.get("k2"); !ok {
+		t.Errorf("expected python entry to remain cached")
+	}
+}
+
+func TestResponseCacheDisabled(t *testing.T) {
+	c := newResponseCache()
+	c.setOptions(CacheOptions{Enabled: false})
+
+	c.put("k1", "file:///a.go", "go", []byte("payload"))
+
+	if _, ok := c.get("k1"); ok {
+		t.Fatalf("expected disabled cache to never hit")
+	}
+}
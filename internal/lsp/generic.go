@@ -0,0 +1,149 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// GenericClient wraps BaseClient for any conforming language server that
+// needs no language-specific handling beyond its launch command and LSP
+// languageId, e.g. rust-analyzer or clangd.
+type GenericClient struct {
+	base       *BaseClient
+	languageID string
+	rootDir    string
+}
+
+// NewGenericClient spawns command (found via PATH) with args and wraps it
+// as a Client reporting languageID on didOpen notifications.
+func NewGenericClient(command string, args []string, languageID, rootDir string) (*GenericClient, error) {
+	binPath, err := exec.LookPath(command)
+	if err != nil {
+		return nil, fmt.Errorf("%s not found in PATH: %w", command, err)
+	}
+
+	base, err := NewBaseClient(binPath, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GenericClient{base: base, languageID: languageID, rootDir: rootDir}, nil
+}
+
+func (c *GenericClient) Initialize(ctx context.Context, rootURI string) error {
+	absPath, err := filepath.Abs(rootURI)
+	if err != nil {
+		absPath = rootURI
+	}
+	return c.base.Initialize(ctx, "file://"+absPath)
+}
+
+func (c *GenericClient) FindReferences(ctx context.Context, uri string, line, column int) ([]Reference, error) {
+	params := ReferenceParams{
+		TextDocumentPositionParams: TextDocumentPositionParams{
+			TextDocument: TextDocumentIdentifier{URI: uri},
+			Position:     Position{Line: line - 1, Character: column},
+		},
+		Context: ReferenceContext{IncludeDeclaration: true},
+	}
+
+	result, err := c.base.Call(ctx, "textDocument/references", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []Location
+	if err := json.Unmarshal(result, &locations); err != nil {
+		return nil, fmt.Errorf("failed to parse references: %w", err)
+	}
+
+	refs := make([]Reference, len(locations))
+	for i, loc := range locations {
+		refs[i] = Reference{URI: loc.URI, Line: loc.Range.Start.Line + 1, Column: loc.Range.Start.Character}
+	}
+	return refs, nil
+}
+
+func (c *GenericClient) GoToDefinition(ctx context.Context, uri string, line, column int) (*Definition, error) {
+	params := TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line - 1, Character: column},
+	}
+
+	result, err := c.base.Call(ctx, "textDocument/definition", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var loc Location
+	if err := json.Unmarshal(result, &loc); err != nil {
+		return nil, fmt.Errorf("failed to parse definition: %w", err)
+	}
+
+	return &Definition{URI: loc.URI, Line: loc.Range.Start.Line + 1, Column: loc.Range.Start.Character}, nil
+}
+
+func (c *GenericClient) Shutdown() error {
+	return c.base.Shutdown()
+}
+
+func (c *GenericClient) IsInitialized() bool {
+	return c.base.IsInitialized()
+}
+
+func (c *GenericClient) DidOpen(uri, content string) error {
+	return c.base.DidOpen(uri, c.languageID, content)
+}
+
+func (c *GenericClient) DidClose(uri string) error {
+	return c.base.DidClose(uri)
+}
+
+func (c *GenericClient) Diagnostics(ctx context.Context, uri string) ([]Diagnostic, error) {
+	return c.base.Diagnostics(ctx, uri)
+}
+
+func (c *GenericClient) CodeActions(ctx context.Context, uri string, rng Range, only []string) ([]CodeAction, error) {
+	return c.base.CodeActions(ctx, uri, rng, only)
+}
+
+func (c *GenericClient) PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]CallHierarchyItem, error) {
+	return c.base.PrepareCallHierarchy(ctx, uri, line, column)
+}
+
+func (c *GenericClient) IncomingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyIncomingCall, error) {
+	return c.base.IncomingCalls(ctx, item)
+}
+
+func (c *GenericClient) OutgoingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyOutgoingCall, error) {
+	return c.base.OutgoingCalls(ctx, item)
+}
+
+func (c *GenericClient) Rename(ctx context.Context, uri string, line, column int, newName string) (*WorkspaceEdit, error) {
+	return c.base.Rename(ctx, uri, line, column, newName)
+}
+
+func (c *GenericClient) WorkspaceSymbols(ctx context.Context, query string) ([]SymbolInformation, error) {
+	return c.base.WorkspaceSymbols(ctx, query)
+}
+
+func (c *GenericClient) DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error) {
+	return c.base.DocumentSymbol(ctx, uri)
+}
+
+func (c *GenericClient) DocumentSymbols(ctx context.Context, uri string) ([]DocumentSymbol, error) {
+	return c.base.DocumentSymbols(ctx, uri)
+}
+
+func (c *GenericClient) FoldingRange(ctx context.Context, uri string) ([]FoldingRange, error) {
+	return c.base.FoldingRange(ctx, uri)
+}
+
+func (c *GenericClient) Hover(ctx context.Context, uri string, line, column int) (string, error) {
+	return c.base.Hover(ctx, uri, line, column)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 type GoClient struct {
@@ -14,15 +16,23 @@ type GoClient struct {
 }
 
 func NewGoClient(rootDir string) (*GoClient, error) {
-	goplsPath, err := exec.LookPath("gopls")
+	spec, ok := getServerSpec(models.LanguageGo)
+	if !ok {
+		spec = ServerSpec{Command: "gopls", Args: []string{"serve"}}
+	}
+
+	binPath, err := exec.LookPath(spec.Command)
 	if err != nil {
-		return nil, fmt.Errorf("gopls not found in PATH: %w", err)
+		return nil, fmt.Errorf("%s not found in PATH: %w", spec.Command, err)
 	}
 
-	base, err := NewBaseClient(goplsPath, "serve")
+	base, err := NewBaseClient(binPath, spec.Args...)
 	if err != nil {
 		return nil, err
 	}
+	if len(spec.InitOptions) > 0 {
+		base.SetInitializationOptions(spec.InitOptions)
+	}
 
 	return &GoClient{
 		base:    base,
@@ -108,3 +118,47 @@ func (c *GoClient) DidOpen(uri, content string) error {
 func (c *GoClient) DidClose(uri string) error {
 	return c.base.DidClose(uri)
 }
+
+func (c *GoClient) Diagnostics(ctx context.Context, uri string) ([]Diagnostic, error) {
+	return c.base.Diagnostics(ctx, uri)
+}
+
+func (c *GoClient) CodeActions(ctx context.Context, uri string, rng Range, only []string) ([]CodeAction, error) {
+	return c.base.CodeActions(ctx, uri, rng, only)
+}
+
+func (c *GoClient) PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]CallHierarchyItem, error) {
+	return c.base.PrepareCallHierarchy(ctx, uri, line, column)
+}
+
+func (c *GoClient) IncomingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyIncomingCall, error) {
+	return c.base.IncomingCalls(ctx, item)
+}
+
+func (c *GoClient) OutgoingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyOutgoingCall, error) {
+	return c.base.OutgoingCalls(ctx, item)
+}
+
+func (c *GoClient) Rename(ctx context.Context, uri string, line, column int, newName string) (*WorkspaceEdit, error) {
+	return c.base.Rename(ctx, uri, line, column, newName)
+}
+
+func (c *GoClient) WorkspaceSymbols(ctx context.Context, query string) ([]SymbolInformation, error) {
+	return c.base.WorkspaceSymbols(ctx, query)
+}
+
+func (c *GoClient) DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error) {
+	return c.base.DocumentSymbol(ctx, uri)
+}
+
+func (c *GoClient) DocumentSymbols(ctx context.Context, uri string) ([]DocumentSymbol, error) {
+	return c.base.DocumentSymbols(ctx, uri)
+}
+
+func (c *GoClient) FoldingRange(ctx context.Context, uri string) ([]FoldingRange, error) {
+	return c.base.FoldingRange(ctx, uri)
+}
+
+func (c *GoClient) Hover(ctx context.Context, uri string, line, column int) (string, error) {
+	return c.base.Hover(ctx, uri, line, column)
+}
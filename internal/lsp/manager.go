@@ -2,8 +2,12 @@ package lsp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 type Reference struct {
@@ -23,6 +27,19 @@ type Client interface {
 	Initialize(ctx context.Context, rootURI string) error
 	FindReferences(ctx context.Context, uri string, line, column int) ([]Reference, error)
 	GoToDefinition(ctx context.Context, uri string, line, column int) (*Definition, error)
+	DidOpen(uri, content string) error
+	DidClose(uri string) error
+	Diagnostics(ctx context.Context, uri string) ([]Diagnostic, error)
+	CodeActions(ctx context.Context, uri string, rng Range, only []string) ([]CodeAction, error)
+	PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]CallHierarchyItem, error)
+	IncomingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyIncomingCall, error)
+	OutgoingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyOutgoingCall, error)
+	Rename(ctx context.Context, uri string, line, column int, newName string) (*WorkspaceEdit, error)
+	WorkspaceSymbols(ctx context.Context, query string) ([]SymbolInformation, error)
+	DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error)
+	DocumentSymbols(ctx context.Context, uri string) ([]DocumentSymbol, error)
+	FoldingRange(ctx context.Context, uri string) ([]FoldingRange, error)
+	Hover(ctx context.Context, uri string, line, column int) (string, error)
 	Shutdown() error
 	IsInitialized() bool
 }
@@ -30,12 +47,59 @@ type Client interface {
 type Manager struct {
 	clients map[string]Client
 	mu      sync.RWMutex
+
+	registry *Registry
+	rootURI  string
+	rootDir  string
+
+	cache *responseCache
 }
 
 func NewManager() *Manager {
 	return &Manager{
-		clients: make(map[string]Client),
+		clients:  make(map[string]Client),
+		registry: NewRegistry(),
+		cache:    newResponseCache(),
+	}
+}
+
+// SetCacheOptions reconfigures the LSP response cache's size budget, TTL,
+// and enabled state.
+func (m *Manager) SetCacheOptions(opts CacheOptions) {
+	m.cache.setOptions(opts)
+}
+
+// CacheStats reports cumulative response-cache hits, misses, and
+// evictions.
+func (m *Manager) CacheStats() CacheStats {
+	return m.cache.snapshotStats()
+}
+
+// ApplyConfig re-reads cache-related settings from cfg, so a subscriber to
+// a live config.Loader can keep the response cache sized and TTL'd the way
+// the user currently has it configured, without restarting the process.
+func (m *Manager) ApplyConfig(cfg *models.Config) {
+	if cfg == nil {
+		return
 	}
+
+	opts := CacheOptions{Enabled: cfg.LSPCache.Enabled}
+	if cfg.LSPCache.MaxBytesMB > 0 {
+		opts.MaxBytes = int64(cfg.LSPCache.MaxBytesMB) * 1024 * 1024
+	}
+	if cfg.LSPCache.TTLSeconds > 0 {
+		opts.TTL = time.Duration(cfg.LSPCache.TTLSeconds) * time.Second
+	}
+	m.SetCacheOptions(opts)
+}
+
+// SetRoot records the workspace root so ReferencesAt/DefinitionAt can lazily
+// spawn a language client the first time a language is needed.
+func (m *Manager) SetRoot(rootURI, rootDir string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rootURI = rootURI
+	m.rootDir = rootDir
 }
 
 func (m *Manager) Register(language string, client Client) {
@@ -64,12 +128,20 @@ func (m *Manager) Initialize(ctx context.Context, rootURI string, languages []st
 			if err := client.Initialize(ctx, rootURI); err != nil {
 				return fmt.Errorf("failed to initialize LSP for %s: %w", lang, err)
 			}
+			m.cache.invalidateLanguage(lang)
 		}
 	}
 
 	return nil
 }
 
+// InvalidateURI drops any cached LSP responses for uri, e.g. after
+// ApplyWorkspaceEdit writes to it or a filesystem watcher reports it
+// changed.
+func (m *Manager) InvalidateURI(uri string) {
+	m.cache.invalidateURI(uri)
+}
+
 func (m *Manager) FindReferences(ctx context.Context, language, uri string, line, column int) ([]Reference, error) {
 	m.mu.RLock()
 	client, ok := m.clients[language]
@@ -79,7 +151,27 @@ func (m *Manager) FindReferences(ctx context.Context, language, uri string, line
 		return nil, fmt.Errorf("no LSP client for language: %s", language)
 	}
 
-	return client.FindReferences(ctx, uri, line, column)
+	hash, cacheable := hashFileContent(uri)
+	if !cacheable {
+		return client.FindReferences(ctx, uri, line, column)
+	}
+
+	key := cacheKey(language, "textDocument/references", uri, line, column, hash)
+	if cached, ok := m.cache.get(key); ok {
+		var refs []Reference
+		if err := json.Unmarshal(cached, &refs); err == nil {
+			return refs, nil
+		}
+	}
+
+	refs, err := client.FindReferences(ctx, uri, line, column)
+	if err != nil {
+		return nil, err
+	}
+	if payload, err := json.Marshal(refs); err == nil {
+		m.cache.put(key, uri, language, payload)
+	}
+	return refs, nil
 }
 
 func (m *Manager) GoToDefinition(ctx context.Context, language, uri string, line, column int) (*Definition, error) {
@@ -91,6 +183,257 @@ func (m *Manager) GoToDefinition(ctx context.Context, language, uri string, line
 		return nil, fmt.Errorf("no LSP client for language: %s", language)
 	}
 
+	hash, cacheable := hashFileContent(uri)
+	if !cacheable {
+		return client.GoToDefinition(ctx, uri, line, column)
+	}
+
+	key := cacheKey(language, "textDocument/definition", uri, line, column, hash)
+	if cached, ok := m.cache.get(key); ok {
+		var def Definition
+		if err := json.Unmarshal(cached, &def); err == nil {
+			return &def, nil
+		}
+	}
+
+	def, err := client.GoToDefinition(ctx, uri, line, column)
+	if err != nil {
+		return nil, err
+	}
+	if payload, err := json.Marshal(def); err == nil {
+		m.cache.put(key, uri, language, payload)
+	}
+	return def, nil
+}
+
+// DocumentSymbol dispatches textDocument/documentSymbol to the registered
+// client for language.
+func (m *Manager) DocumentSymbol(ctx context.Context, language, uri string) ([]models.Symbol, error) {
+	m.mu.RLock()
+	client, ok := m.clients[language]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no LSP client for language: %s", language)
+	}
+
+	hash, cacheable := hashFileContent(uri)
+	if !cacheable {
+		return client.DocumentSymbol(ctx, uri)
+	}
+
+	key := cacheKey(language, "textDocument/documentSymbol#flat", uri, 0, 0, hash)
+	if cached, ok := m.cache.get(key); ok {
+		var symbols []models.Symbol
+		if err := json.Unmarshal(cached, &symbols); err == nil {
+			return symbols, nil
+		}
+	}
+
+	symbols, err := client.DocumentSymbol(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	if payload, err := json.Marshal(symbols); err == nil {
+		m.cache.put(key, uri, language, payload)
+	}
+	return symbols, nil
+}
+
+// DocumentSymbols dispatches textDocument/documentSymbol to the registered
+// client for language, returning the server's hierarchical result as-is.
+func (m *Manager) DocumentSymbols(ctx context.Context, language, uri string) ([]DocumentSymbol, error) {
+	m.mu.RLock()
+	client, ok := m.clients[language]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no LSP client for language: %s", language)
+	}
+
+	hash, cacheable := hashFileContent(uri)
+	if !cacheable {
+		return client.DocumentSymbols(ctx, uri)
+	}
+
+	key := cacheKey(language, "textDocument/documentSymbol", uri, 0, 0, hash)
+	if cached, ok := m.cache.get(key); ok {
+		var symbols []DocumentSymbol
+		if err := json.Unmarshal(cached, &symbols); err == nil {
+			return symbols, nil
+		}
+	}
+
+	symbols, err := client.DocumentSymbols(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	if payload, err := json.Marshal(symbols); err == nil {
+		m.cache.put(key, uri, language, payload)
+	}
+	return symbols, nil
+}
+
+// CodeActions dispatches textDocument/codeAction to the registered client
+// for language.
+func (m *Manager) CodeActions(ctx context.Context, language, uri string, rng Range, only []string) ([]CodeAction, error) {
+	m.mu.RLock()
+	client, ok := m.clients[language]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no LSP client for language: %s", language)
+	}
+
+	return client.CodeActions(ctx, uri, rng, only)
+}
+
+// Rename dispatches textDocument/rename to the registered client for
+// language.
+func (m *Manager) Rename(ctx context.Context, language, uri string, line, column int, newName string) (*WorkspaceEdit, error) {
+	m.mu.RLock()
+	client, ok := m.clients[language]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no LSP client for language: %s", language)
+	}
+
+	return client.Rename(ctx, uri, line, column, newName)
+}
+
+// WorkspaceSymbols dispatches workspace/symbol to the registered client
+// for language.
+func (m *Manager) WorkspaceSymbols(ctx context.Context, language, query string) ([]SymbolInformation, error) {
+	m.mu.RLock()
+	client, ok := m.clients[language]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no LSP client for language: %s", language)
+	}
+
+	return client.WorkspaceSymbols(ctx, query)
+}
+
+// FoldingRange dispatches textDocument/foldingRange to the registered
+// client for language.
+func (m *Manager) FoldingRange(ctx context.Context, language, uri string) ([]FoldingRange, error) {
+	m.mu.RLock()
+	client, ok := m.clients[language]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no LSP client for language: %s", language)
+	}
+
+	hash, cacheable := hashFileContent(uri)
+	if !cacheable {
+		return client.FoldingRange(ctx, uri)
+	}
+
+	key := cacheKey(language, "textDocument/foldingRange", uri, 0, 0, hash)
+	if cached, ok := m.cache.get(key); ok {
+		var ranges []FoldingRange
+		if err := json.Unmarshal(cached, &ranges); err == nil {
+			return ranges, nil
+		}
+	}
+
+	ranges, err := client.FoldingRange(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	if payload, err := json.Marshal(ranges); err == nil {
+		m.cache.put(key, uri, language, payload)
+	}
+	return ranges, nil
+}
+
+// Hover dispatches textDocument/hover to the registered client for language.
+func (m *Manager) Hover(ctx context.Context, language, uri string, line, column int) (string, error) {
+	m.mu.RLock()
+	client, ok := m.clients[language]
+	m.mu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("no LSP client for language: %s", language)
+	}
+
+	hash, cacheable := hashFileContent(uri)
+	if !cacheable {
+		return client.Hover(ctx, uri, line, column)
+	}
+
+	key := cacheKey(language, "textDocument/hover", uri, line, column, hash)
+	if cached, ok := m.cache.get(key); ok {
+		return string(cached), nil
+	}
+
+	text, err := client.Hover(ctx, uri, line, column)
+	if err != nil {
+		return "", err
+	}
+	m.cache.put(key, uri, language, []byte(text))
+	return text, nil
+}
+
+// ensureClient returns the already-registered client for lang if present,
+// otherwise builds and initializes one via the registry and registers it
+// under lang's string key so later lookups (including the Register/GetClient
+// path used elsewhere) see the same instance.
+func (m *Manager) ensureClient(ctx context.Context, lang models.Language) (Client, error) {
+	key := string(lang)
+
+	m.mu.RLock()
+	client, ok := m.clients[key]
+	m.mu.RUnlock()
+	if ok {
+		return client, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if client, ok := m.clients[key]; ok {
+		return client, nil
+	}
+
+	if !m.registry.Detect(lang) {
+		return nil, fmt.Errorf("no LSP server available for language: %s", lang)
+	}
+
+	client, err := m.registry.New(lang, m.rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Initialize(ctx, m.rootURI); err != nil {
+		return nil, fmt.Errorf("failed to initialize LSP for %s: %w", lang, err)
+	}
+
+	m.clients[key] = client
+	m.cache.invalidateLanguage(key)
+	return client, nil
+}
+
+// ReferencesAt auto-detects uri's language and dispatches to its client,
+// spawning one via the registry on first use.
+func (m *Manager) ReferencesAt(ctx context.Context, uri string, line, column int) ([]Reference, error) {
+	client, err := m.ensureClient(ctx, languageForURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	return client.FindReferences(ctx, uri, line, column)
+}
+
+// DefinitionAt auto-detects uri's language and dispatches to its client,
+// spawning one via the registry on first use.
+func (m *Manager) DefinitionAt(ctx context.Context, uri string, line, column int) (*Definition, error) {
+	client, err := m.ensureClient(ctx, languageForURI(uri))
+	if err != nil {
+		return nil, err
+	}
 	return client.GoToDefinition(ctx, uri, line, column)
 }
 
@@ -3,6 +3,8 @@ package lsp
 import (
 	"context"
 	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 func TestNewManager(t *testing.T) {
@@ -176,3 +178,55 @@ func (m *mockClient) Shutdown() error {
 func (m *mockClient) IsInitialized() bool {
 	return m.initialized
 }
+
+func (m *mockClient) DidOpen(uri, content string) error {
+	return nil
+}
+
+func (m *mockClient) DidClose(uri string) error {
+	return nil
+}
+
+func (m *mockClient) Diagnostics(ctx context.Context, uri string) ([]Diagnostic, error) {
+	return nil, nil
+}
+
+func (m *mockClient) CodeActions(ctx context.Context, uri string, rng Range, only []string) ([]CodeAction, error) {
+	return nil, nil
+}
+
+func (m *mockClient) PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]CallHierarchyItem, error) {
+	return nil, nil
+}
+
+func (m *mockClient) IncomingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyIncomingCall, error) {
+	return nil, nil
+}
+
+func (m *mockClient) OutgoingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyOutgoingCall, error) {
+	return nil, nil
+}
+
+func (m *mockClient) Rename(ctx context.Context, uri string, line, column int, newName string) (*WorkspaceEdit, error) {
+	return nil, nil
+}
+
+func (m *mockClient) WorkspaceSymbols(ctx context.Context, query string) ([]SymbolInformation, error) {
+	return nil, nil
+}
+
+func (m *mockClient) DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error) {
+	return nil, nil
+}
+
+func (m *mockClient) DocumentSymbols(ctx context.Context, uri string) ([]DocumentSymbol, error) {
+	return nil, nil
+}
+
+func (m *mockClient) FoldingRange(ctx context.Context, uri string) ([]FoldingRange, error) {
+	return nil, nil
+}
+
+func (m *mockClient) Hover(ctx context.Context, uri string, line, column int) (string, error) {
+	return "", nil
+}
@@ -1,17 +1,19 @@
 package lsp
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	"github.com/alexkarsten/reducto/internal/rpc"
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 type Position struct {
@@ -48,9 +50,10 @@ type ReferenceParams struct {
 }
 
 type InitializeParams struct {
-	ProcessID    int                    `json:"processId"`
-	RootURI      string                 `json:"rootUri"`
-	Capabilities map[string]interface{} `json:"capabilities"`
+	ProcessID             int                    `json:"processId"`
+	RootURI               string                 `json:"rootUri"`
+	Capabilities          map[string]interface{} `json:"capabilities"`
+	InitializationOptions interface{}            `json:"initializationOptions,omitempty"`
 }
 
 type InitializeResult struct {
@@ -61,11 +64,76 @@ type BaseClient struct {
 	cmd          *exec.Cmd
 	stdin        io.WriteCloser
 	stdout       io.Reader
+	codec        *rpc.StreamCodec
 	requestID    atomic.Int64
 	pending      map[int64]chan json.RawMessage
 	pendingMu    sync.Mutex
 	initialized  bool
 	shutdownOnce sync.Once
+
+	notifyMu       sync.Mutex
+	notifyHandlers map[string][]func(json.RawMessage)
+
+	diagMu      sync.RWMutex
+	diagCache   map[string]diagnosticsEntry
+	diagWaiters map[string][]chan struct{}
+
+	initOptions interface{}
+
+	defaultTimeout time.Duration
+}
+
+// SetInitializationOptions sets the server-specific initializationOptions
+// payload sent on the next Initialize call, e.g. pyright's
+// python.analysis.* settings. Must be called before Initialize.
+func (c *BaseClient) SetInitializationOptions(opts interface{}) {
+	c.initOptions = opts
+}
+
+// SetDefaultTimeout bounds Call by d whenever the caller's ctx carries no
+// deadline of its own, so References/Definition and similar wrappers don't
+// have to hand-wire a context.WithTimeout at every call site. Zero (the
+// default) leaves such calls bounded only by ctx.
+func (c *BaseClient) SetDefaultTimeout(d time.Duration) {
+	c.defaultTimeout = d
+}
+
+// Diagnostic mirrors LSP's Diagnostic, as reported via
+// textDocument/publishDiagnostics.
+type Diagnostic struct {
+	Range    Range       `json:"range"`
+	Severity int         `json:"severity,omitempty"`
+	Code     interface{} `json:"code,omitempty"`
+	Source   string      `json:"source,omitempty"`
+	Message  string      `json:"message"`
+}
+
+// CodeAction mirrors LSP's CodeAction result for textDocument/codeAction.
+// A server returns the fix as Edit when it can express it as a plain
+// WorkspaceEdit, or as Command when applying it requires the server's own
+// logic (e.g. an extract-function refactor); ApplyWorkspaceEdit only
+// handles the former.
+type CodeAction struct {
+	Title       string         `json:"title"`
+	Kind        string         `json:"kind,omitempty"`
+	Diagnostics []Diagnostic   `json:"diagnostics,omitempty"`
+	IsPreferred bool           `json:"isPreferred,omitempty"`
+	Edit        *WorkspaceEdit `json:"edit,omitempty"`
+	Command     *Command       `json:"command,omitempty"`
+}
+
+// Command mirrors LSP's Command, naming a server-side command (reached via
+// workspace/executeCommand) rather than an inline edit the client can
+// apply itself.
+type Command struct {
+	Title     string        `json:"title"`
+	Command   string        `json:"command"`
+	Arguments []interface{} `json:"arguments,omitempty"`
+}
+
+type diagnosticsEntry struct {
+	version     int
+	diagnostics []Diagnostic
 }
 
 func NewBaseClient(command string, args ...string) (*BaseClient, error) {
@@ -91,65 +159,504 @@ func NewBaseClient(command string, args ...string) (*BaseClient, error) {
 	}
 
 	client := &BaseClient{
-		cmd:     cmd,
-		stdin:   stdin,
-		stdout:  stdout,
-		pending: make(map[int64]chan json.RawMessage),
+		cmd:            cmd,
+		stdin:          stdin,
+		stdout:         stdout,
+		codec:          rpc.NewStreamCodec(stdout, stdin),
+		pending:        make(map[int64]chan json.RawMessage),
+		notifyHandlers: make(map[string][]func(json.RawMessage)),
+		diagCache:      make(map[string]diagnosticsEntry),
+		diagWaiters:    make(map[string][]chan struct{}),
 	}
 
+	client.OnNotification("textDocument/publishDiagnostics", client.cachePublishDiagnostics)
+
 	go client.readResponses()
 
 	return client, nil
 }
 
 func (c *BaseClient) readResponses() {
-	reader := bufio.NewReader(c.stdout)
-
-	var contentLength int
 	for {
-		line, err := reader.ReadString('\n')
+		body, err := c.codec.ReadMessage()
 		if err != nil {
 			return
 		}
-		line = strings.TrimSpace(line)
-
-		if line == "" {
-			if contentLength > 0 {
-				body := make([]byte, contentLength)
-				if _, err := io.ReadFull(reader, body); err != nil {
-					return
-				}
-
-				var response struct {
-					ID     int64           `json:"id"`
-					Result json.RawMessage `json:"result"`
-					Error  *struct {
-						Code    int    `json:"code"`
-						Message string `json:"message"`
-					} `json:"error"`
-				}
-
-				if err := json.Unmarshal(body, &response); err == nil {
-					c.pendingMu.Lock()
-					if ch, ok := c.pending[response.ID]; ok {
-						delete(c.pending, response.ID)
-						ch <- body
-					}
-					c.pendingMu.Unlock()
-				}
-				contentLength = 0
-			}
-			continue
+		c.dispatch(body)
+	}
+}
+
+// dispatch routes an incoming frame as one of three kinds: a response to a
+// pending Call (an id, no method), a server-initiated notification (a
+// method, no id) routed to any handlers registered via OnNotification, or a
+// server-initiated request (both a method and an id, e.g.
+// workDoneProgress/create) which gets an empty success result acked back —
+// some servers stall waiting for that ack rather than treating it as
+// optional.
+func (c *BaseClient) dispatch(body []byte) {
+	var msg struct {
+		ID     *int64          `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return
+	}
+
+	switch {
+	case msg.Method != "" && msg.ID != nil:
+		c.handleServerRequest(*msg.ID, msg.Method, msg.Params)
+	case msg.Method != "":
+		c.handleNotification(msg.Method, msg.Params)
+	case msg.ID != nil:
+		c.pendingMu.Lock()
+		if ch, ok := c.pending[*msg.ID]; ok {
+			delete(c.pending, *msg.ID)
+			ch <- body
 		}
+		c.pendingMu.Unlock()
+	}
+}
+
+// handleServerRequest acks a server-initiated request with a null result.
+// BaseClient doesn't act on any of these (workDoneProgress/create and
+// similar are advisory), but several servers block waiting for the
+// response, so they can't be dropped the way notifications are.
+func (c *BaseClient) handleServerRequest(id int64, method string, params json.RawMessage) {
+	response := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"result":  nil,
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+
+	c.codec.WriteMessage(body)
+}
 
-		if strings.HasPrefix(line, "Content-Length: ") {
-			lengthStr := strings.TrimPrefix(line, "Content-Length: ")
-			contentLength, _ = strconv.Atoi(lengthStr)
+// OnNotification registers handler to run whenever the server sends a
+// notification for method (e.g. "textDocument/publishDiagnostics"). Multiple
+// handlers for the same method are all invoked, in registration order.
+func (c *BaseClient) OnNotification(method string, handler func(json.RawMessage)) {
+	c.notifyMu.Lock()
+	defer c.notifyMu.Unlock()
+	c.notifyHandlers[method] = append(c.notifyHandlers[method], handler)
+}
+
+func (c *BaseClient) handleNotification(method string, params json.RawMessage) {
+	c.notifyMu.Lock()
+	handlers := append([]func(json.RawMessage){}, c.notifyHandlers[method]...)
+	c.notifyMu.Unlock()
+
+	for _, h := range handlers {
+		h(params)
+	}
+}
+
+func (c *BaseClient) cachePublishDiagnostics(raw json.RawMessage) {
+	var params struct {
+		URI         string       `json:"uri"`
+		Version     int          `json:"version"`
+		Diagnostics []Diagnostic `json:"diagnostics"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return
+	}
+
+	c.diagMu.Lock()
+	c.diagCache[params.URI] = diagnosticsEntry{version: params.Version, diagnostics: params.Diagnostics}
+	waiters := c.diagWaiters[params.URI]
+	delete(c.diagWaiters, params.URI)
+	c.diagMu.Unlock()
+
+	for _, ch := range waiters {
+		close(ch)
+	}
+}
+
+// Diagnostics returns the diagnostics most recently published for uri. If
+// none have arrived yet, it waits for the next publishDiagnostics
+// notification for uri or for ctx to be done, whichever comes first.
+func (c *BaseClient) Diagnostics(ctx context.Context, uri string) ([]Diagnostic, error) {
+	c.diagMu.Lock()
+	if entry, ok := c.diagCache[uri]; ok {
+		diags := append([]Diagnostic{}, entry.diagnostics...)
+		c.diagMu.Unlock()
+		return diags, nil
+	}
+	ch := make(chan struct{})
+	c.diagWaiters[uri] = append(c.diagWaiters[uri], ch)
+	c.diagMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-ch:
+		c.diagMu.RLock()
+		defer c.diagMu.RUnlock()
+		return append([]Diagnostic{}, c.diagCache[uri].diagnostics...), nil
+	}
+}
+
+// CodeActions wraps textDocument/codeAction, passing the URI's currently
+// cached diagnostics as context so servers can offer quick fixes for them.
+// only restricts the results to the given CodeActionKinds (e.g.
+// "refactor.extract", "source.fixAll"); pass nil for no restriction.
+func (c *BaseClient) CodeActions(ctx context.Context, uri string, rng Range, only []string) ([]CodeAction, error) {
+	actionContext := map[string]interface{}{
+		"diagnostics": c.cachedDiagnostics(uri),
+	}
+	if len(only) > 0 {
+		actionContext["only"] = only
+	}
+
+	params := map[string]interface{}{
+		"textDocument": TextDocumentIdentifier{URI: uri},
+		"range":        rng,
+		"context":      actionContext,
+	}
+
+	result, err := c.Call(ctx, "textDocument/codeAction", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []CodeAction
+	if err := json.Unmarshal(result, &actions); err != nil {
+		return nil, fmt.Errorf("failed to parse code actions: %w", err)
+	}
+	return actions, nil
+}
+
+func (c *BaseClient) cachedDiagnostics(uri string) []Diagnostic {
+	c.diagMu.RLock()
+	defer c.diagMu.RUnlock()
+	return append([]Diagnostic{}, c.diagCache[uri].diagnostics...)
+}
+
+// CallHierarchyItem mirrors LSP's CallHierarchyItem.
+type CallHierarchyItem struct {
+	Name           string `json:"name"`
+	Kind           int    `json:"kind"`
+	URI            string `json:"uri"`
+	Range          Range  `json:"range"`
+	SelectionRange Range  `json:"selectionRange"`
+}
+
+// CallHierarchyIncomingCall mirrors LSP's CallHierarchyIncomingCall.
+type CallHierarchyIncomingCall struct {
+	From       CallHierarchyItem `json:"from"`
+	FromRanges []Range           `json:"fromRanges"`
+}
+
+// CallHierarchyOutgoingCall mirrors LSP's CallHierarchyOutgoingCall.
+type CallHierarchyOutgoingCall struct {
+	To         CallHierarchyItem `json:"to"`
+	FromRanges []Range           `json:"fromRanges"`
+}
+
+// PrepareCallHierarchy wraps textDocument/prepareCallHierarchy, resolving
+// the symbol at (line, column) into the CallHierarchyItem(s) that anchor
+// IncomingCalls/OutgoingCalls. Servers may return more than one candidate
+// when the position is ambiguous; callers typically want the first.
+func (c *BaseClient) PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]CallHierarchyItem, error) {
+	params := TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line - 1, Character: column},
+	}
+
+	result, err := c.Call(ctx, "textDocument/prepareCallHierarchy", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []CallHierarchyItem
+	if err := json.Unmarshal(result, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse call hierarchy items: %w", err)
+	}
+	return items, nil
+}
+
+// TextEdit mirrors LSP's TextEdit: a single replacement within a document.
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// WorkspaceEdit mirrors LSP's WorkspaceEdit, keyed by document URI.
+type WorkspaceEdit struct {
+	Changes map[string][]TextEdit `json:"changes"`
+}
+
+// SymbolInformation mirrors LSP's SymbolInformation, as returned by
+// workspace/symbol.
+type SymbolInformation struct {
+	Name          string   `json:"name"`
+	Kind          int      `json:"kind"`
+	Location      Location `json:"location"`
+	ContainerName string   `json:"containerName,omitempty"`
+}
+
+// Rename wraps textDocument/rename, resolving the symbol at (line, column)
+// and returning the set of edits, across every affected file, needed to
+// rename it to newName. Callers should apply every file in the returned
+// WorkspaceEdit.Changes, not just uri, since a rename can touch references
+// in other files.
+func (c *BaseClient) Rename(ctx context.Context, uri string, line, column int, newName string) (*WorkspaceEdit, error) {
+	params := map[string]interface{}{
+		"textDocument": TextDocumentIdentifier{URI: uri},
+		"position":     Position{Line: line - 1, Character: column},
+		"newName":      newName,
+	}
+
+	result, err := c.Call(ctx, "textDocument/rename", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var edit WorkspaceEdit
+	if err := json.Unmarshal(result, &edit); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace edit: %w", err)
+	}
+	return &edit, nil
+}
+
+// WorkspaceSymbols wraps workspace/symbol, resolving query to matching
+// symbol declarations anywhere in the workspace in a single round trip.
+func (c *BaseClient) WorkspaceSymbols(ctx context.Context, query string) ([]SymbolInformation, error) {
+	result, err := c.Call(ctx, "workspace/symbol", map[string]interface{}{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	var symbols []SymbolInformation
+	if err := json.Unmarshal(result, &symbols); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace symbols: %w", err)
+	}
+	return symbols, nil
+}
+
+// DocumentSymbol mirrors LSP's hierarchical DocumentSymbol result, as
+// opposed to the flat SymbolInformation shape used by workspace/symbol.
+type DocumentSymbol struct {
+	Name           string           `json:"name"`
+	Detail         string           `json:"detail,omitempty"`
+	Kind           int              `json:"kind"`
+	Range          Range            `json:"range"`
+	SelectionRange Range            `json:"selectionRange"`
+	Children       []DocumentSymbol `json:"children,omitempty"`
+}
+
+// symbolKindNames maps LSP's SymbolKind enum to the lowercase type strings
+// reducto's own symbol extractors (internal/parser, internal/treesitter)
+// already use, so callers can treat an LSP-backed result and a
+// regex/AST-backed one interchangeably.
+var symbolKindNames = map[int]string{
+	5:  "class",
+	6:  "method",
+	8:  "field",
+	9:  "constructor",
+	11: "interface",
+	12: "function",
+	23: "struct",
+}
+
+func symbolKindName(kind int) string {
+	if name, ok := symbolKindNames[kind]; ok {
+		return name
+	}
+	return "symbol"
+}
+
+// DocumentSymbol wraps textDocument/documentSymbol, flattening the server's
+// hierarchical result into []models.Symbol with each method/nested symbol
+// carrying its enclosing container's name.
+func (c *BaseClient) DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error) {
+	params := map[string]interface{}{
+		"textDocument": TextDocumentIdentifier{URI: uri},
+	}
+
+	result, err := c.Call(ctx, "textDocument/documentSymbol", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []DocumentSymbol
+	if err := json.Unmarshal(result, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse document symbols: %w", err)
+	}
+
+	var symbols []models.Symbol
+	flattenDocumentSymbols(nodes, uri, "", &symbols)
+	return symbols, nil
+}
+
+// DocumentSymbols wraps textDocument/documentSymbol, returning the
+// server's hierarchical result as-is (unlike DocumentSymbol, which
+// flattens it into []models.Symbol) for callers that need the
+// parent/child nesting itself, e.g. to scope a rename or extract-function
+// CodeAction to the right enclosing symbol.
+func (c *BaseClient) DocumentSymbols(ctx context.Context, uri string) ([]DocumentSymbol, error) {
+	params := map[string]interface{}{
+		"textDocument": TextDocumentIdentifier{URI: uri},
+	}
+
+	result, err := c.Call(ctx, "textDocument/documentSymbol", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []DocumentSymbol
+	if err := json.Unmarshal(result, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse document symbols: %w", err)
+	}
+	return nodes, nil
+}
+
+func flattenDocumentSymbols(nodes []DocumentSymbol, uri, container string, out *[]models.Symbol) {
+	for _, node := range nodes {
+		*out = append(*out, models.Symbol{
+			Name:          node.Name,
+			Type:          symbolKindName(node.Kind),
+			File:          uri,
+			StartLine:     node.Range.Start.Line + 1,
+			EndLine:       node.Range.End.Line + 1,
+			Signature:     node.Detail,
+			ContainerName: container,
+		})
+		if len(node.Children) > 0 {
+			flattenDocumentSymbols(node.Children, uri, node.Name, out)
+		}
+	}
+}
+
+// FoldingRange is one range from textDocument/foldingRange, 0-indexed the
+// same as Range. The parser package uses it to recover a symbol's true
+// closing line when documentSymbol's own Range under- or over-shoots
+// (trailing blank lines, attached comments, multi-line signatures).
+type FoldingRange struct {
+	StartLine int    `json:"startLine"`
+	EndLine   int    `json:"endLine"`
+	Kind      string `json:"kind,omitempty"`
+}
+
+// FoldingRange wraps textDocument/foldingRange.
+func (c *BaseClient) FoldingRange(ctx context.Context, uri string) ([]FoldingRange, error) {
+	params := map[string]interface{}{
+		"textDocument": TextDocumentIdentifier{URI: uri},
+	}
+
+	result, err := c.Call(ctx, "textDocument/foldingRange", params)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 || string(result) == "null" {
+		return nil, nil
+	}
+
+	var ranges []FoldingRange
+	if err := json.Unmarshal(result, &ranges); err != nil {
+		return nil, fmt.Errorf("failed to parse folding ranges: %w", err)
+	}
+	return ranges, nil
+}
+
+// Hover wraps textDocument/hover, returning the server's rendered
+// documentation/type info for the symbol at (line, column) as plain text
+// (markdown content's "value" field, if that's the shape the server used).
+func (c *BaseClient) Hover(ctx context.Context, uri string, line, column int) (string, error) {
+	params := TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line - 1, Character: column},
+	}
+
+	result, err := c.Call(ctx, "textDocument/hover", params)
+	if err != nil {
+		return "", err
+	}
+	if len(result) == 0 || string(result) == "null" {
+		return "", nil
+	}
+
+	var hover struct {
+		Contents json.RawMessage `json:"contents"`
+	}
+	if err := json.Unmarshal(result, &hover); err != nil {
+		return "", fmt.Errorf("failed to parse hover result: %w", err)
+	}
+
+	return extractHoverText(hover.Contents), nil
+}
+
+// extractHoverText handles the three shapes textDocument/hover's contents
+// can take: a plain string, a single MarkupContent/MarkedString object, or
+// an array of MarkedString — concatenating the value/text of each.
+func extractHoverText(raw json.RawMessage) string {
+	var asString string
+	if err := json.Unmarshal(raw, &asString); err == nil {
+		return asString
+	}
+
+	var asObject struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &asObject); err == nil && asObject.Value != "" {
+		return asObject.Value
+	}
+
+	var asArray []struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &asArray); err == nil {
+		var parts []string
+		for _, item := range asArray {
+			parts = append(parts, item.Value)
 		}
+		return strings.Join(parts, "\n")
+	}
+
+	return ""
+}
+
+// IncomingCalls wraps callHierarchy/incomingCalls: who calls item.
+func (c *BaseClient) IncomingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyIncomingCall, error) {
+	result, err := c.Call(ctx, "callHierarchy/incomingCalls", map[string]interface{}{"item": item})
+	if err != nil {
+		return nil, err
+	}
+
+	var calls []CallHierarchyIncomingCall
+	if err := json.Unmarshal(result, &calls); err != nil {
+		return nil, fmt.Errorf("failed to parse incoming calls: %w", err)
+	}
+	return calls, nil
+}
+
+// OutgoingCalls wraps callHierarchy/outgoingCalls: who item calls.
+func (c *BaseClient) OutgoingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyOutgoingCall, error) {
+	result, err := c.Call(ctx, "callHierarchy/outgoingCalls", map[string]interface{}{"item": item})
+	if err != nil {
+		return nil, err
 	}
+
+	var calls []CallHierarchyOutgoingCall
+	if err := json.Unmarshal(result, &calls); err != nil {
+		return nil, fmt.Errorf("failed to parse outgoing calls: %w", err)
+	}
+	return calls, nil
 }
 
 func (c *BaseClient) Call(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	if c.defaultTimeout > 0 {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.defaultTimeout)
+			defer cancel()
+		}
+	}
+
 	id := c.requestID.Add(1)
 
 	request := map[string]interface{}{
@@ -164,21 +671,25 @@ func (c *BaseClient) Call(ctx context.Context, method string, params interface{}
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	// Buffered so dispatch's send to ch never blocks even after this Call
+	// has already returned via the ctx.Done() case below.
 	ch := make(chan json.RawMessage, 1)
 	c.pendingMu.Lock()
 	c.pending[id] = ch
 	c.pendingMu.Unlock()
 
-	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(body))
-	if _, err := c.stdin.Write([]byte(header)); err != nil {
-		return nil, fmt.Errorf("failed to write header: %w", err)
-	}
-	if _, err := c.stdin.Write(body); err != nil {
-		return nil, fmt.Errorf("failed to write body: %w", err)
+	if err := c.codec.WriteMessage(body); err != nil {
+		c.removePending(id)
+		return nil, err
 	}
 
 	select {
 	case <-ctx.Done():
+		c.removePending(id)
+		// Best-effort: ask the server to abort the in-flight request, the
+		// way gopls and rust-analyzer expect. Its own response, if any,
+		// arrives with no pending entry left to match it and is dropped.
+		_ = c.Notify("$/cancelRequest", map[string]interface{}{"id": id})
 		return nil, ctx.Err()
 	case response := <-ch:
 		var result struct {
@@ -201,10 +712,20 @@ func (c *BaseClient) Call(ctx context.Context, method string, params interface{}
 	}
 }
 
+// removePending drops id's response channel, e.g. after ctx fires, so a
+// late reply from the server is silently discarded instead of being held
+// onto forever.
+func (c *BaseClient) removePending(id int64) {
+	c.pendingMu.Lock()
+	delete(c.pending, id)
+	c.pendingMu.Unlock()
+}
+
 func (c *BaseClient) Initialize(ctx context.Context, rootURI string) error {
 	params := InitializeParams{
-		ProcessID: os.Getpid(),
-		RootURI:   rootURI,
+		ProcessID:             os.Getpid(),
+		RootURI:               rootURI,
+		InitializationOptions: c.initOptions,
 		Capabilities: map[string]interface{}{
 			"textDocument": map[string]interface{}{
 				"references": map[string]interface{}{
@@ -267,15 +788,7 @@ func (c *BaseClient) Notify(method string, params interface{}) error {
 		return fmt.Errorf("failed to marshal notification: %w", err)
 	}
 
-	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(body))
-	if _, err := c.stdin.Write([]byte(header)); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
-	}
-	if _, err := c.stdin.Write(body); err != nil {
-		return fmt.Errorf("failed to write body: %w", err)
-	}
-
-	return nil
+	return c.codec.WriteMessage(body)
 }
 
 func (c *BaseClient) DidOpen(uri, languageID, content string) error {
@@ -0,0 +1,237 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alexkarsten/reducto/internal/rpc"
+)
+
+// discardWriteCloser satisfies io.WriteCloser for tests that exercise
+// BaseClient.Call without a real subprocess on the other end of stdin.
+type discardWriteCloser struct{}
+
+func (discardWriteCloser) Write(p []byte) (int, error) { return len(p), nil }
+func (discardWriteCloser) Close() error                { return nil }
+
+// capturingWriteCloser records every Write, for tests that need to inspect
+// what BaseClient sent over stdin.
+type capturingWriteCloser struct {
+	written [][]byte
+}
+
+func (w *capturingWriteCloser) Write(p []byte) (int, error) {
+	w.written = append(w.written, append([]byte{}, p...))
+	return len(p), nil
+}
+func (w *capturingWriteCloser) Close() error { return nil }
+
+func newTestBaseClient() *BaseClient {
+	stdin := discardWriteCloser{}
+	c := &BaseClient{
+		stdin:          stdin,
+		codec:          rpc.NewStreamCodec(nil, stdin),
+		pending:        make(map[int64]chan json.RawMessage),
+		notifyHandlers: make(map[string][]func(json.RawMessage)),
+		diagCache:      make(map[string]diagnosticsEntry),
+		diagWaiters:    make(map[string][]chan struct{}),
+	}
+	c.OnNotification("textDocument/publishDiagnostics", c.cachePublishDiagnostics)
+	return c
+}
+
+var _ io.WriteCloser = discardWriteCloser{}
+
+func TestDispatchRoutesResponseToPendingCall(t *testing.T) {
+	c := newTestBaseClient()
+
+	ch := make(chan json.RawMessage, 1)
+	c.pendingMu.Lock()
+	c.pending[7] = ch
+	c.pendingMu.Unlock()
+
+	c.dispatch([]byte(`{"id":7,"result":{"ok":true}}`))
+
+	select {
+	case body := <-ch:
+		var got struct {
+			Result struct {
+				OK bool `json:"ok"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("failed to parse dispatched body: %v", err)
+		}
+		if !got.Result.OK {
+			t.Error("expected result.ok = true")
+		}
+	default:
+		t.Fatal("expected response to be delivered to pending channel")
+	}
+}
+
+func TestDispatchRoutesNotificationToHandler(t *testing.T) {
+	c := newTestBaseClient()
+
+	received := make(chan json.RawMessage, 1)
+	c.OnNotification("textDocument/publishDiagnostics", func(params json.RawMessage) {
+		received <- params
+	})
+
+	c.dispatch([]byte(`{"method":"textDocument/publishDiagnostics","params":{"uri":"file:///a.go","version":1,"diagnostics":[]}}`))
+
+	select {
+	case <-received:
+	default:
+		t.Fatal("expected notification handler to be invoked")
+	}
+}
+
+func TestDispatchAcksServerInitiatedRequest(t *testing.T) {
+	c := newTestBaseClient()
+	stdin := &capturingWriteCloser{}
+	c.stdin = stdin
+	c.codec = rpc.NewStreamCodec(nil, stdin)
+
+	c.dispatch([]byte(`{"id":3,"method":"window/workDoneProgress/create","params":{}}`))
+
+	if len(stdin.written) != 2 {
+		t.Fatalf("expected a Content-Length header and a body to be written, got %d writes", len(stdin.written))
+	}
+
+	var ack struct {
+		ID     int64       `json:"id"`
+		Result interface{} `json:"result"`
+	}
+	if err := json.Unmarshal(stdin.written[1], &ack); err != nil {
+		t.Fatalf("failed to parse ack body: %v", err)
+	}
+	if ack.ID != 3 {
+		t.Errorf("expected ack id 3, got %d", ack.ID)
+	}
+}
+
+func TestCallRemovesPendingEntryOnContextCancel(t *testing.T) {
+	c := newTestBaseClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Call(ctx, "textDocument/hover", nil); err == nil {
+		t.Fatal("expected Call to return an error when ctx is already done")
+	}
+
+	c.pendingMu.Lock()
+	n := len(c.pending)
+	c.pendingMu.Unlock()
+	if n != 0 {
+		t.Fatalf("expected pending map to be empty after cancellation, got %d entries", n)
+	}
+}
+
+func TestCallAppliesDefaultTimeoutWhenCtxHasNoDeadline(t *testing.T) {
+	c := newTestBaseClient()
+	c.SetDefaultTimeout(10 * time.Millisecond)
+
+	start := time.Now()
+	_, err := c.Call(context.Background(), "textDocument/hover", nil)
+	if err == nil {
+		t.Fatal("expected Call to time out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Call took %v, expected it to be bounded by the default timeout", elapsed)
+	}
+}
+
+func TestDiagnosticsReturnsCachedValueImmediately(t *testing.T) {
+	c := newTestBaseClient()
+	c.dispatch([]byte(`{"method":"textDocument/publishDiagnostics","params":{"uri":"file:///a.go","version":1,"diagnostics":[{"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":1}},"message":"unused import"}]}}`))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	diags, err := c.Diagnostics(ctx, "file:///a.go")
+	if err != nil {
+		t.Fatalf("Diagnostics() error = %v", err)
+	}
+	if len(diags) != 1 || diags[0].Message != "unused import" {
+		t.Errorf("Diagnostics() = %+v, want one diagnostic with message %q", diags, "unused import")
+	}
+}
+
+func TestDiagnosticsTimesOutWhenNonePublished(t *testing.T) {
+	c := newTestBaseClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.Diagnostics(ctx, "file:///never.go"); err == nil {
+		t.Error("Diagnostics() expected context deadline error, got nil")
+	}
+}
+
+func TestRenameParsesWorkspaceEdit(t *testing.T) {
+	c := newTestBaseClient()
+
+	done := make(chan *WorkspaceEdit, 1)
+	errs := make(chan error, 1)
+	go func() {
+		edit, err := c.Rename(context.Background(), "file:///a.go", 10, 4, "newName")
+		done <- edit
+		errs <- err
+	}()
+
+	waitForPendingCall(t, c, 1)
+	c.dispatch([]byte(`{"id":1,"result":{"changes":{"file:///a.go":[{"range":{"start":{"line":9,"character":4},"end":{"line":9,"character":7}},"newText":"newName"}]}}}`))
+
+	if err := <-errs; err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	edit := <-done
+	if len(edit.Changes["file:///a.go"]) != 1 || edit.Changes["file:///a.go"][0].NewText != "newName" {
+		t.Errorf("Rename() = %+v, want one edit with NewText newName", edit)
+	}
+}
+
+func TestWorkspaceSymbolParsesResults(t *testing.T) {
+	c := newTestBaseClient()
+
+	done := make(chan []SymbolInformation, 1)
+	errs := make(chan error, 1)
+	go func() {
+		symbols, err := c.WorkspaceSymbols(context.Background(), "Handler")
+		done <- symbols
+		errs <- err
+	}()
+
+	waitForPendingCall(t, c, 1)
+	c.dispatch([]byte(`{"id":1,"result":[{"name":"Handler","kind":12,"location":{"uri":"file:///a.go","range":{"start":{"line":4,"character":0},"end":{"line":4,"character":7}}}}]}`))
+
+	if err := <-errs; err != nil {
+		t.Fatalf("WorkspaceSymbols() error = %v", err)
+	}
+	symbols := <-done
+	if len(symbols) != 1 || symbols[0].Name != "Handler" {
+		t.Errorf("WorkspaceSymbols() = %+v, want one symbol named Handler", symbols)
+	}
+}
+
+// waitForPendingCall polls until Call has registered its response channel
+// under id, so the test can dispatch a matching response without a race.
+func waitForPendingCall(t *testing.T, c *BaseClient, id int64) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		c.pendingMu.Lock()
+		_, ok := c.pending[id]
+		c.pendingMu.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for pending call id %d to be registered", id)
+}
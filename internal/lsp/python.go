@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 type PythonClient struct {
@@ -14,6 +16,21 @@ type PythonClient struct {
 }
 
 func NewPythonClient(rootDir string) (*PythonClient, error) {
+	// A registered ServerSpec that diverges from the built-in default
+	// means the user has pointed Python at an alternative server
+	// (pylsp, jedi-language-server, ...); honor it directly and skip
+	// the pyright-then-pylsp auto-detection below.
+	if spec, ok := getServerSpec(models.LanguagePython); ok && spec.Command != "pyright" && binaryOnPath(spec) {
+		base, err := NewBaseClient(spec.Command, spec.Args...)
+		if err != nil {
+			return nil, err
+		}
+		if len(spec.InitOptions) > 0 {
+			base.SetInitializationOptions(spec.InitOptions)
+		}
+		return &PythonClient{base: base, rootDir: rootDir}, nil
+	}
+
 	pyrightPath, err := exec.LookPath("pyright")
 	if err != nil {
 		pylspPath, err2 := exec.LookPath("pylsp")
@@ -34,6 +51,9 @@ func NewPythonClient(rootDir string) (*PythonClient, error) {
 	if err != nil {
 		return nil, err
 	}
+	if spec, ok := getServerSpec(models.LanguagePython); ok && len(spec.InitOptions) > 0 {
+		base.SetInitializationOptions(spec.InitOptions)
+	}
 
 	return &PythonClient{
 		base:    base,
@@ -119,3 +139,47 @@ func (c *PythonClient) DidOpen(uri, content string) error {
 func (c *PythonClient) DidClose(uri string) error {
 	return c.base.DidClose(uri)
 }
+
+func (c *PythonClient) Diagnostics(ctx context.Context, uri string) ([]Diagnostic, error) {
+	return c.base.Diagnostics(ctx, uri)
+}
+
+func (c *PythonClient) CodeActions(ctx context.Context, uri string, rng Range, only []string) ([]CodeAction, error) {
+	return c.base.CodeActions(ctx, uri, rng, only)
+}
+
+func (c *PythonClient) PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]CallHierarchyItem, error) {
+	return c.base.PrepareCallHierarchy(ctx, uri, line, column)
+}
+
+func (c *PythonClient) IncomingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyIncomingCall, error) {
+	return c.base.IncomingCalls(ctx, item)
+}
+
+func (c *PythonClient) OutgoingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyOutgoingCall, error) {
+	return c.base.OutgoingCalls(ctx, item)
+}
+
+func (c *PythonClient) Rename(ctx context.Context, uri string, line, column int, newName string) (*WorkspaceEdit, error) {
+	return c.base.Rename(ctx, uri, line, column, newName)
+}
+
+func (c *PythonClient) WorkspaceSymbols(ctx context.Context, query string) ([]SymbolInformation, error) {
+	return c.base.WorkspaceSymbols(ctx, query)
+}
+
+func (c *PythonClient) DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error) {
+	return c.base.DocumentSymbol(ctx, uri)
+}
+
+func (c *PythonClient) DocumentSymbols(ctx context.Context, uri string) ([]DocumentSymbol, error) {
+	return c.base.DocumentSymbols(ctx, uri)
+}
+
+func (c *PythonClient) FoldingRange(ctx context.Context, uri string) ([]FoldingRange, error) {
+	return c.base.FoldingRange(ctx, uri)
+}
+
+func (c *PythonClient) Hover(ctx context.Context, uri string, line, column int) (string, error) {
+	return c.base.Hover(ctx, uri, line, column)
+}
@@ -0,0 +1,132 @@
+package lsp
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// ClientFactory constructs a Client rooted at rootDir. The built-in
+// factories start the underlying language server process when they build
+// the Client; its Initialize then performs the LSP handshake.
+type ClientFactory func(rootDir string) (Client, error)
+
+// Registry maps a models.Language to the factory that builds its Client
+// and a detector that reports whether a conforming server binary is on
+// PATH, so callers can auto-detect support before spawning anything.
+type Registry struct {
+	mu        sync.Mutex
+	factories map[models.Language]ClientFactory
+	detectors map[models.Language]func() bool
+}
+
+// NewRegistry returns a Registry pre-populated with the built-in language
+// clients shipped with reducto.
+func NewRegistry() *Registry {
+	r := &Registry{
+		factories: make(map[models.Language]ClientFactory),
+		detectors: make(map[models.Language]func() bool),
+	}
+	r.registerBuiltins()
+	return r
+}
+
+func (r *Registry) registerBuiltins() {
+	r.Register(models.LanguageGo,
+		func(root string) (Client, error) { return NewGoClient(root) },
+		binaryAvailable("gopls"))
+
+	r.Register(models.LanguagePython,
+		func(root string) (Client, error) { return NewPythonClient(root) },
+		func() bool { return binaryAvailable("pyright")() || binaryAvailable("pylsp")() })
+
+	tsFactory := func(root string) (Client, error) { return NewTypeScriptClient(root) }
+	tsDetect := binaryAvailable("typescript-language-server")
+	r.Register(models.LanguageTypeScript, tsFactory, tsDetect)
+	r.Register(models.LanguageJavaScript, tsFactory, tsDetect)
+
+	r.Register(models.LanguageRust,
+		func(root string) (Client, error) {
+			return NewGenericClient("rust-analyzer", nil, "rust", root)
+		},
+		binaryAvailable("rust-analyzer"))
+
+	cppFactory := func(root string) (Client, error) {
+		return NewGenericClient("clangd", nil, "cpp", root)
+	}
+	cppDetect := binaryAvailable("clangd")
+	r.Register(models.LanguageC, cppFactory, cppDetect)
+	r.Register(models.LanguageCPP, cppFactory, cppDetect)
+}
+
+// Register adds or overrides the factory and detector for lang, letting
+// callers plug in alternative servers without recompiling the registry.
+func (r *Registry) Register(lang models.Language, factory ClientFactory, detect func() bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[lang] = factory
+	r.detectors[lang] = detect
+}
+
+// Detect reports whether a server binary is available on PATH for lang.
+func (r *Registry) Detect(lang models.Language) bool {
+	r.mu.Lock()
+	detect, ok := r.detectors[lang]
+	r.mu.Unlock()
+	if !ok || detect == nil {
+		return false
+	}
+	return detect()
+}
+
+// New builds a fresh Client for lang rooted at rootDir via the registered
+// factory.
+func (r *Registry) New(lang models.Language, rootDir string) (Client, error) {
+	r.mu.Lock()
+	factory, ok := r.factories[lang]
+	r.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no LSP client registered for language: %s", lang)
+	}
+	return factory(rootDir)
+}
+
+func binaryAvailable(name string) func() bool {
+	return func() bool {
+		_, err := exec.LookPath(name)
+		return err == nil
+	}
+}
+
+// languageForURI infers a models.Language from a file URI's extension,
+// duplicating walker.DetectLanguage's extension table in miniature so the
+// lsp package doesn't need to depend on walker just to route a request.
+func languageForURI(uri string) models.Language {
+	name := strings.TrimPrefix(uri, "file://")
+	ext := ""
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		ext = strings.ToLower(name[idx:])
+	}
+
+	switch ext {
+	case ".go":
+		return models.LanguageGo
+	case ".py":
+		return models.LanguagePython
+	case ".ts", ".tsx":
+		return models.LanguageTypeScript
+	case ".js", ".jsx":
+		return models.LanguageJavaScript
+	case ".rs":
+		return models.LanguageRust
+	case ".c", ".h":
+		return models.LanguageC
+	case ".cpp", ".cc", ".cxx", ".hpp":
+		return models.LanguageCPP
+	default:
+		return ""
+	}
+}
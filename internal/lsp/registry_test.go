@@ -0,0 +1,32 @@
+package lsp
+
+import "testing"
+
+func TestRegistryDetectUnknownLanguage(t *testing.T) {
+	r := NewRegistry()
+	if r.Detect("cobol") {
+		t.Error("Detect() = true for an unregistered language, want false")
+	}
+}
+
+func TestRegistryNewUnknownLanguage(t *testing.T) {
+	r := NewRegistry()
+	if _, err := r.New("cobol", "."); err == nil {
+		t.Error("New() error = nil for an unregistered language, want error")
+	}
+}
+
+func TestLanguageForURI(t *testing.T) {
+	cases := map[string]string{
+		"file:///a/b.go":  "go",
+		"file:///a/b.py":  "python",
+		"file:///a/b.rs":  "rust",
+		"file:///a/b.cpp": "cpp",
+		"file:///a/b.xyz": "",
+	}
+	for uri, want := range cases {
+		if got := string(languageForURI(uri)); got != want {
+			t.Errorf("languageForURI(%q) = %q, want %q", uri, got, want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package lsp
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ServerSpec describes how to launch and configure a language server for a
+// models.Language, so users can point reducto at any conforming LSP
+// (rust-analyzer, clangd, sourcekit-lsp, lua-language-server, ...) without
+// recompiling.
+type ServerSpec struct {
+	Command     string                 `yaml:"command"`
+	Args        []string               `yaml:"args"`
+	InitOptions map[string]interface{} `yaml:"init_options"`
+	RootMarkers []string               `yaml:"root_markers"`
+}
+
+var (
+	serverSpecsMu sync.Mutex
+	serverSpecs   = map[models.Language]ServerSpec{
+		models.LanguageGo: {
+			Command:     "gopls",
+			Args:        []string{"serve"},
+			RootMarkers: []string{"go.mod"},
+		},
+		models.LanguagePython: {
+			// Matches NewPythonClient's built-in pyright-then-pylsp
+			// detection; an override whose Command is not pyright and
+			// is on PATH skips that fallback and uses Command/Args as
+			// given.
+			Command:     "pyright",
+			Args:        []string{"--outputjson"},
+			RootMarkers: []string{"pyproject.toml", "setup.py", "requirements.txt"},
+		},
+		models.LanguageTypeScript: {
+			Command:     "typescript-language-server",
+			Args:        []string{"--stdio"},
+			RootMarkers: []string{"tsconfig.json", "package.json"},
+		},
+		models.LanguageJavaScript: {
+			Command:     "typescript-language-server",
+			Args:        []string{"--stdio"},
+			RootMarkers: []string{"package.json"},
+		},
+		models.LanguageRust: {
+			Command:     "rust-analyzer",
+			RootMarkers: []string{"Cargo.toml"},
+		},
+		models.LanguageC: {
+			Command:     "clangd",
+			RootMarkers: []string{"compile_commands.json"},
+		},
+		models.LanguageCPP: {
+			Command:     "clangd",
+			RootMarkers: []string{"compile_commands.json"},
+		},
+	}
+)
+
+// RegisterServer overrides (or adds) the ServerSpec used for lang, letting
+// callers plug in alternative language servers without recompiling.
+func RegisterServer(lang models.Language, spec ServerSpec) {
+	serverSpecsMu.Lock()
+	defer serverSpecsMu.Unlock()
+	serverSpecs[lang] = spec
+}
+
+func getServerSpec(lang models.Language) (ServerSpec, bool) {
+	serverSpecsMu.Lock()
+	defer serverSpecsMu.Unlock()
+	spec, ok := serverSpecs[lang]
+	return spec, ok
+}
+
+// NewClient builds a Client for lang rooted at rootDir from its registered
+// ServerSpec, honoring any configured initializationOptions.
+func NewClient(lang models.Language, rootDir string) (Client, error) {
+	spec, ok := getServerSpec(lang)
+	if !ok {
+		return nil, fmt.Errorf("no LSP server registered for language: %s", lang)
+	}
+
+	client, err := NewGenericClient(spec.Command, spec.Args, string(lang), rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.InitOptions) > 0 {
+		client.base.SetInitializationOptions(spec.InitOptions)
+	}
+	return client, nil
+}
+
+// DefaultServerConfigPath returns ~/.reducto/lsp.yaml, the conventional
+// location for user-provided ServerSpec overrides.
+func DefaultServerConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".reducto", "lsp.yaml"), nil
+}
+
+// LoadServerConfig reads a YAML file mapping language name to server
+// overrides and merges each entry onto the corresponding built-in
+// ServerSpec: fields left unset in the override keep their built-in value,
+// so a user who only wants to change Args doesn't have to repeat Command.
+// A missing file is not an error; it just means no overrides apply.
+func LoadServerConfig(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read LSP server config %s: %w", path, err)
+	}
+
+	var overrides map[string]ServerSpec
+	if err := yaml.Unmarshal(raw, &overrides); err != nil {
+		return fmt.Errorf("failed to parse LSP server config %s: %w", path, err)
+	}
+
+	for langName, override := range overrides {
+		lang := models.Language(langName)
+		merged, _ := getServerSpec(lang)
+
+		if override.Command != "" {
+			merged.Command = override.Command
+		}
+		if len(override.Args) > 0 {
+			merged.Args = override.Args
+		}
+		if len(override.RootMarkers) > 0 {
+			merged.RootMarkers = override.RootMarkers
+		}
+		if len(override.InitOptions) > 0 {
+			if merged.InitOptions == nil {
+				merged.InitOptions = make(map[string]interface{})
+			}
+			for k, v := range override.InitOptions {
+				merged.InitOptions[k] = v
+			}
+		}
+
+		RegisterServer(lang, merged)
+	}
+
+	return nil
+}
+
+// binaryOnPath reports whether spec's command resolves via PATH, so callers
+// can decide whether to fall back to an alternative server.
+func binaryOnPath(spec ServerSpec) bool {
+	_, err := exec.LookPath(spec.Command)
+	return err == nil
+}
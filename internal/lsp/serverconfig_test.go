@@ -0,0 +1,67 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestRegisterServerOverridesBuiltinDefault(t *testing.T) {
+	const testLang = models.Language("lsp-test-lang")
+	RegisterServer(testLang, ServerSpec{Command: "fake-lsp", Args: []string{"--foo"}})
+
+	spec, ok := getServerSpec(testLang)
+	if !ok {
+		t.Fatal("getServerSpec() ok = false, want true after RegisterServer")
+	}
+	if spec.Command != "fake-lsp" {
+		t.Errorf("Command = %q, want fake-lsp", spec.Command)
+	}
+}
+
+func TestNewClientUnknownLanguage(t *testing.T) {
+	_, err := NewClient(models.Language("no-such-language"), t.TempDir())
+	if err == nil {
+		t.Fatal("NewClient() error = nil, want error for unregistered language")
+	}
+}
+
+func TestLoadServerConfigMergesOntoBuiltinDefault(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lsp.yaml")
+	contents := `
+go:
+  args: ["serve", "-rpc.trace"]
+  init_options:
+    usePlaceholders: true
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if err := LoadServerConfig(path); err != nil {
+		t.Fatalf("LoadServerConfig() error = %v", err)
+	}
+
+	spec, ok := getServerSpec(models.LanguageGo)
+	if !ok {
+		t.Fatal("getServerSpec(go) ok = false")
+	}
+	if spec.Command != "gopls" {
+		t.Errorf("Command = %q, want gopls (unset override should keep built-in default)", spec.Command)
+	}
+	if len(spec.Args) != 2 || spec.Args[1] != "-rpc.trace" {
+		t.Errorf("Args = %v, want overridden args", spec.Args)
+	}
+	if spec.InitOptions["usePlaceholders"] != true {
+		t.Errorf("InitOptions = %v, want usePlaceholders=true", spec.InitOptions)
+	}
+}
+
+func TestLoadServerConfigMissingFileIsNotAnError(t *testing.T) {
+	if err := LoadServerConfig(filepath.Join(t.TempDir(), "missing.yaml")); err != nil {
+		t.Errorf("LoadServerConfig() error = %v, want nil for missing file", err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 type TypeScriptClient struct {
@@ -14,6 +16,17 @@ type TypeScriptClient struct {
 }
 
 func NewTypeScriptClient(rootDir string) (*TypeScriptClient, error) {
+	if spec, ok := getServerSpec(models.LanguageTypeScript); ok && spec.Command != "typescript-language-server" && binaryOnPath(spec) {
+		base, err := NewBaseClient(spec.Command, spec.Args...)
+		if err != nil {
+			return nil, err
+		}
+		if len(spec.InitOptions) > 0 {
+			base.SetInitializationOptions(spec.InitOptions)
+		}
+		return &TypeScriptClient{base: base, rootDir: rootDir}, nil
+	}
+
 	typescriptServerPath, err := exec.LookPath("typescript-language-server")
 	if err != nil {
 		tsserverPath, err2 := exec.LookPath("tsserver")
@@ -34,6 +47,9 @@ func NewTypeScriptClient(rootDir string) (*TypeScriptClient, error) {
 	if err != nil {
 		return nil, err
 	}
+	if spec, ok := getServerSpec(models.LanguageTypeScript); ok && len(spec.InitOptions) > 0 {
+		base.SetInitializationOptions(spec.InitOptions)
+	}
 
 	return &TypeScriptClient{
 		base:    base,
@@ -119,3 +135,47 @@ func (c *TypeScriptClient) DidOpen(uri, content string) error {
 func (c *TypeScriptClient) DidClose(uri string) error {
 	return c.base.DidClose(uri)
 }
+
+func (c *TypeScriptClient) Diagnostics(ctx context.Context, uri string) ([]Diagnostic, error) {
+	return c.base.Diagnostics(ctx, uri)
+}
+
+func (c *TypeScriptClient) CodeActions(ctx context.Context, uri string, rng Range, only []string) ([]CodeAction, error) {
+	return c.base.CodeActions(ctx, uri, rng, only)
+}
+
+func (c *TypeScriptClient) PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]CallHierarchyItem, error) {
+	return c.base.PrepareCallHierarchy(ctx, uri, line, column)
+}
+
+func (c *TypeScriptClient) IncomingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyIncomingCall, error) {
+	return c.base.IncomingCalls(ctx, item)
+}
+
+func (c *TypeScriptClient) OutgoingCalls(ctx context.Context, item CallHierarchyItem) ([]CallHierarchyOutgoingCall, error) {
+	return c.base.OutgoingCalls(ctx, item)
+}
+
+func (c *TypeScriptClient) Rename(ctx context.Context, uri string, line, column int, newName string) (*WorkspaceEdit, error) {
+	return c.base.Rename(ctx, uri, line, column, newName)
+}
+
+func (c *TypeScriptClient) WorkspaceSymbols(ctx context.Context, query string) ([]SymbolInformation, error) {
+	return c.base.WorkspaceSymbols(ctx, query)
+}
+
+func (c *TypeScriptClient) DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error) {
+	return c.base.DocumentSymbol(ctx, uri)
+}
+
+func (c *TypeScriptClient) DocumentSymbols(ctx context.Context, uri string) ([]DocumentSymbol, error) {
+	return c.base.DocumentSymbols(ctx, uri)
+}
+
+func (c *TypeScriptClient) FoldingRange(ctx context.Context, uri string) ([]FoldingRange, error) {
+	return c.base.FoldingRange(ctx, uri)
+}
+
+func (c *TypeScriptClient) Hover(ctx context.Context, uri string, line, column int) (string, error) {
+	return c.base.Hover(ctx, uri, line, column)
+}
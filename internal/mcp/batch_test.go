@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHandleBatchMixedNotificationsAndRequests(t *testing.T) {
+	s := NewServer(t.TempDir())
+
+	reqs, _, err := ParseRequestBatch([]byte(`[
+		{"jsonrpc":"2.0","id":1,"method":"initialize"},
+		{"jsonrpc":"2.0","method":"initialize"},
+		{"jsonrpc":"2.0","id":2,"method":"initialize"}
+	]`))
+	if err != nil {
+		t.Fatalf("ParseRequestBatch failed: %v", err)
+	}
+
+	results := s.HandleBatch(context.Background(), reqs)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 entries (one per request), got %d", len(results))
+	}
+	if results[1] != nil {
+		t.Errorf("expected nil response for the notification, got %+v", results[1])
+	}
+	if results[0] == nil || results[0].ID != float64(1) {
+		t.Errorf("expected response[0] to preserve id 1, got %+v", results[0])
+	}
+	if results[2] == nil || results[2].ID != float64(2) {
+		t.Errorf("expected response[2] to preserve id 2, got %+v", results[2])
+	}
+}
+
+// TestHandleBatchIsolatesPerRequestErrors checks that one request failing
+// (unknown method) doesn't prevent the rest of the batch from completing
+// and doesn't disturb their ordering.
+func TestHandleBatchIsolatesPerRequestErrors(t *testing.T) {
+	s := NewServer(t.TempDir())
+
+	reqs := []*Request{
+		{JSONRPC: JSONRPCVersion, ID: float64(1), Method: "initialize"},
+		{JSONRPC: JSONRPCVersion, ID: float64(2), Method: "this_method_does_not_exist"},
+		{JSONRPC: JSONRPCVersion, ID: float64(3), Method: "initialize"},
+	}
+
+	results := s.HandleBatch(context.Background(), reqs)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(results))
+	}
+	if results[1] == nil || results[1].Error == nil || results[1].Error.Code != MethodNotFound {
+		t.Fatalf("expected MethodNotFound for the unknown method, got %+v", results[1])
+	}
+	if results[0] == nil || results[0].Error != nil {
+		t.Errorf("expected the first request to succeed independently, got %+v", results[0])
+	}
+	if results[2] == nil || results[2].Error != nil {
+		t.Errorf("expected the third request to succeed independently, got %+v", results[2])
+	}
+}
@@ -1,27 +1,57 @@
 package mcp
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
 
-func ApplyUnifiedDiff(original, diff string) (string, error) {
-	lines := strings.Split(original, "\n")
-	diffLines := strings.Split(diff, "\n")
+// RejectedHunk describes a hunk ApplyUnifiedDiff couldn't place, even after
+// searching nearby offsets and trimming context, so a caller can persist it
+// as a .rej file instead of the patch silently corrupting the target.
+type RejectedHunk struct {
+	Index    int
+	OldStart int
+	Reason   string
+	Context  string
+}
 
-	hunks, err := parseHunks(diffLines)
+// ApplyUnifiedDiff applies diff to original the way `patch -p0 --fuzz=3`
+// does: each hunk's context and deletion lines must match what's actually
+// at its stated position, searched a few lines either side when they
+// don't, or the hunk is rejected rather than corrupting the file. Hunks
+// that apply cleanly still apply even when others are rejected.
+func ApplyUnifiedDiff(original, diff string) (string, []RejectedHunk, error) {
+	content, results, err := ApplyUnifiedDiffFuzzy(original, diff, ApplyUnifiedDiffOptions{Fuzz: 3})
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	for i := len(hunks) - 1; i >= 0; i-- {
-		lines, err = applyHunk(lines, hunks[i])
-		if err != nil {
-			return "", err
+	var rejects []RejectedHunk
+	for _, r := range results {
+		if !r.Rejected {
+			continue
 		}
+		rejects = append(rejects, RejectedHunk{
+			Index:    r.HunkIndex,
+			OldStart: r.OldStart,
+			Reason:   r.Reason,
+			Context:  r.Context,
+		})
 	}
 
-	return strings.Join(lines, "\n"), nil
+	return content, rejects, nil
+}
+
+// rejectSummary renders rejects as a human-readable error detail, one hunk
+// per line, for surfacing in an RPC error without requiring the caller to
+// walk the slice itself.
+func rejectSummary(rejects []RejectedHunk) string {
+	var b strings.Builder
+	for _, r := range rejects {
+		fmt.Fprintf(&b, "hunk %d (old line %d): %s\n", r.Index, r.OldStart, r.Reason)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
 }
 
 type hunk struct {
@@ -30,6 +60,11 @@ type hunk struct {
 	newStart int
 	newCount int
 	changes  []diffLine
+
+	// noNewlineAtEOF records that the hunk's final change line was marked
+	// with a "\ No newline at end of file" marker, so the file it produces
+	// must not gain a trailing newline it didn't have (or vice versa).
+	noNewlineAtEOF bool
 }
 
 type diffLine struct {
@@ -39,11 +74,17 @@ type diffLine struct {
 
 var hunkHeaderRegex = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
 
+const noNewlineMarker = "\\ No newline at end of file"
+
 func parseHunks(diffLines []string) ([]hunk, error) {
 	var hunks []hunk
 	var currentHunk *hunk
 
-	for _, line := range diffLines {
+	for _, rawLine := range diffLines {
+		// A diff produced against a CRLF file may still carry a trailing \r
+		// on each line; hunk content is always compared and stored as LF.
+		line := strings.TrimSuffix(rawLine, "\r")
+
 		if strings.HasPrefix(line, "@@ ") {
 			if currentHunk != nil {
 				hunks = append(hunks, *currentHunk)
@@ -71,6 +112,8 @@ func parseHunks(diffLines []string) ([]hunk, error) {
 			} else {
 				currentHunk.newCount = 1
 			}
+		} else if currentHunk != nil && line == noNewlineMarker {
+			currentHunk.noNewlineAtEOF = true
 		} else if currentHunk != nil && len(line) > 0 {
 			kind := line[0]
 			if kind == '+' || kind == '-' || kind == ' ' {
@@ -99,33 +142,15 @@ func parseInt(s string) int {
 	return result
 }
 
-func applyHunk(lines []string, h hunk) ([]string, error) {
-	var result []string
-	lineIdx := 0
-
-	for lineIdx < h.oldStart-1 && lineIdx < len(lines) {
-		result = append(result, lines[lineIdx])
-		lineIdx++
+// renderHunk reconstructs the unified-diff text for h, so a rejected hunk
+// can be written out verbatim as part of a .rej file.
+func renderHunk(h hunk) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", h.oldStart, h.oldCount, h.newStart, h.newCount)
+	for _, c := range h.changes {
+		b.WriteByte(c.kind)
+		b.WriteString(c.content)
+		b.WriteByte('\n')
 	}
-
-	for _, change := range h.changes {
-		switch change.kind {
-		case ' ':
-			if lineIdx < len(lines) {
-				result = append(result, lines[lineIdx])
-				lineIdx++
-			}
-		case '-':
-			lineIdx++
-		case '+':
-			result = append(result, change.content)
-		}
-	}
-
-	for lineIdx < len(lines) {
-		result = append(result, lines[lineIdx])
-		lineIdx++
-	}
-
-	return result, nil
+	return b.String()
 }
@@ -14,7 +14,7 @@ func TestApplyUnifiedDiff_SimpleAddition(t *testing.T) {
  line2
 `
 
-	result, err := ApplyUnifiedDiff(original, diff)
+	result, _, err := ApplyUnifiedDiff(original, diff)
 	if err != nil {
 		t.Fatalf("ApplyUnifiedDiff failed: %v", err)
 	}
@@ -34,7 +34,7 @@ func TestApplyUnifiedDiff_SimpleRemoval(t *testing.T) {
  line3
 `
 
-	result, err := ApplyUnifiedDiff(original, diff)
+	result, _, err := ApplyUnifiedDiff(original, diff)
 	if err != nil {
 		t.Fatalf("ApplyUnifiedDiff failed: %v", err)
 	}
@@ -55,7 +55,7 @@ func TestApplyUnifiedDiff_SimpleModification(t *testing.T) {
  line3
 `
 
-	result, err := ApplyUnifiedDiff(original, diff)
+	result, _, err := ApplyUnifiedDiff(original, diff)
 	if err != nil {
 		t.Fatalf("ApplyUnifiedDiff failed: %v", err)
 	}
@@ -68,7 +68,7 @@ func TestApplyUnifiedDiff_SimpleModification(t *testing.T) {
 func TestApplyUnifiedDiff_EmptyDiff(t *testing.T) {
 	original := "line1\nline2\n"
 
-	result, err := ApplyUnifiedDiff(original, "")
+	result, _, err := ApplyUnifiedDiff(original, "")
 	if err != nil {
 		t.Fatalf("ApplyUnifiedDiff failed: %v", err)
 	}
@@ -87,7 +87,7 @@ func TestApplyUnifiedDiff_NoChange(t *testing.T) {
  line2
 `
 
-	result, err := ApplyUnifiedDiff(original, diff)
+	result, _, err := ApplyUnifiedDiff(original, diff)
 	if err != nil {
 		t.Fatalf("ApplyUnifiedDiff failed: %v", err)
 	}
@@ -155,7 +155,7 @@ func TestParseHunks_MultipleHunks(t *testing.T) {
 	}
 }
 
-func TestApplyHunk_AddLines(t *testing.T) {
+func TestApplyHunkFuzzy_AddLines(t *testing.T) {
 	lines := []string{"line1", "line2"}
 	h := hunk{
 		oldStart: 1,
@@ -169,9 +169,9 @@ func TestApplyHunk_AddLines(t *testing.T) {
 		},
 	}
 
-	result, err := applyHunk(lines, h)
-	if err != nil {
-		t.Fatalf("applyHunk failed: %v", err)
+	result, res, ok := applyHunkFuzzy(lines, h, ApplyUnifiedDiffOptions{}, false)
+	if !ok {
+		t.Fatalf("applyHunkFuzzy rejected: %s", res.Reason)
 	}
 
 	if len(result) != 3 {
@@ -179,7 +179,7 @@ func TestApplyHunk_AddLines(t *testing.T) {
 	}
 }
 
-func TestApplyHunk_RemoveLines(t *testing.T) {
+func TestApplyHunkFuzzy_RemoveLines(t *testing.T) {
 	lines := []string{"line1", "line2", "line3"}
 	h := hunk{
 		oldStart: 1,
@@ -193,12 +193,35 @@ func TestApplyHunk_RemoveLines(t *testing.T) {
 		},
 	}
 
-	result, err := applyHunk(lines, h)
-	if err != nil {
-		t.Fatalf("applyHunk failed: %v", err)
+	result, res, ok := applyHunkFuzzy(lines, h, ApplyUnifiedDiffOptions{}, false)
+	if !ok {
+		t.Fatalf("applyHunkFuzzy rejected: %s", res.Reason)
 	}
 
 	if len(result) != 2 {
 		t.Errorf("Expected 2 lines, got %d", len(result))
 	}
 }
+
+func TestApplyUnifiedDiff_RejectsMismatchedContext(t *testing.T) {
+	original := "line1\nline2\nline3\n"
+	diff := `--- a/file.txt
++++ b/file.txt
+@@ -1,3 +1,3 @@
+ line1
+-does not match
++newline
+ line3
+`
+
+	_, rejects, err := ApplyUnifiedDiff(original, diff)
+	if err != nil {
+		t.Fatalf("ApplyUnifiedDiff failed: %v", err)
+	}
+	if len(rejects) != 1 {
+		t.Fatalf("Expected 1 rejected hunk, got %d", len(rejects))
+	}
+	if rejects[0].OldStart != 1 {
+		t.Errorf("Expected rejected hunk OldStart 1, got %d", rejects[0].OldStart)
+	}
+}
@@ -0,0 +1,258 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ApplyUnifiedDiffOptions controls the fuzzy-matching behavior of
+// ApplyUnifiedDiffFuzzy, mirroring the classic GNU patch --fuzz algorithm.
+type ApplyUnifiedDiffOptions struct {
+	// Fuzz is the maximum number of lines a hunk's context may be searched
+	// for on either side of its stated oldStart before giving up.
+	Fuzz int
+	// IgnoreWhitespace collapses runs of whitespace when comparing context
+	// lines (the ' ' kind). Deletion lines always require an exact match.
+	IgnoreWhitespace bool
+}
+
+// ApplyResult reports, per hunk, how ApplyUnifiedDiffFuzzy resolved it.
+type ApplyResult struct {
+	HunkIndex      int
+	AppliedAt      int
+	OffsetUsed     int
+	ContextTrimmed int
+	Rejected       bool
+	Reason         string
+	// OldStart and Context are only populated when Rejected is true, so a
+	// caller can persist the hunk as a .rej file.
+	OldStart int
+	Context  string
+}
+
+// ApplyUnifiedDiffFuzzy applies diff to original the way `patch -p0 --fuzz`
+// does: it first tries the hunk's exact oldStart, then searches offsets
+// ±1, ±2, ... ±opts.Fuzz, and as a last resort progressively drops leading
+// and trailing context lines before giving up and rejecting the hunk.
+// Hunks that apply cleanly are still applied even when others reject.
+func ApplyUnifiedDiffFuzzy(original, diff string, opts ApplyUnifiedDiffOptions) (string, []ApplyResult, error) {
+	crlf := strings.Contains(original, "\r\n")
+	lines := strings.Split(original, "\n")
+	diffLines := strings.Split(diff, "\n")
+
+	hunks, err := parseHunks(diffLines)
+	if err != nil {
+		return "", nil, err
+	}
+
+	results := make([]ApplyResult, len(hunks))
+	applied := make([]bool, len(hunks))
+
+	// Bottom-up, each hunk onto the previous one's output: a hunk's changes
+	// then never shift the lines that a hunk above it is matched against.
+	for i := len(hunks) - 1; i >= 0; i-- {
+		out, res, ok := applyHunkFuzzy(lines, hunks[i], opts, crlf)
+		res.HunkIndex = i
+		results[i] = res
+		if ok {
+			applied[i] = true
+			lines = out
+			hunks[i].oldStart += res.OffsetUsed
+		}
+	}
+
+	if err := checkOverlaps(hunks, applied); err != nil {
+		return "", results, err
+	}
+
+	var lastApplied *hunk
+	for i := len(hunks) - 1; i >= 0; i-- {
+		if applied[i] {
+			if lastApplied == nil || hunks[i].newStart > lastApplied.newStart {
+				lastApplied = &hunks[i]
+			}
+		}
+	}
+
+	// A hunk marked "\ No newline at end of file" means the reconstructed
+	// content must not carry the trailing empty element strings.Split left
+	// from the original file's own trailing newline.
+	if lastApplied != nil && lastApplied.noNewlineAtEOF && len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n"), results, nil
+}
+
+// checkOverlaps returns an error when the old-file ranges of two applied
+// hunks overlap, each taken at the position it applied at (ApplyUnifiedDiffFuzzy
+// has already shifted oldStart by the fuzz offset used). The whole diff then
+// fails; no single hunk is rejected in favour of the other.
+func checkOverlaps(hunks []hunk, applied []bool) error {
+	type span struct{ start, end int }
+	var spans []span
+	for i, h := range hunks {
+		if !applied[i] {
+			continue
+		}
+		start := h.oldStart - 1
+		end := start + h.oldCount
+		for _, s := range spans {
+			if start < s.end && end > s.start {
+				return fmt.Errorf("overlapping hunks after fuzz matching")
+			}
+		}
+		spans = append(spans, span{start, end})
+	}
+	return nil
+}
+
+func applyHunkFuzzy(lines []string, h hunk, opts ApplyUnifiedDiffOptions, crlf bool) ([]string, ApplyResult, bool) {
+	before := beforeLines(h)
+	// kinds[i] is the kind (' ' or '-') of before[i]: only context lines may
+	// be trimmed away or compared with whitespace collapsed.
+	var kinds []byte
+	for _, c := range h.changes {
+		if c.kind == ' ' || c.kind == '-' {
+			kinds = append(kinds, c.kind)
+		}
+	}
+
+	for trim := 0; trim <= len(before); trim++ {
+		trimmedBefore, leadTrim := trimContext(before, trim)
+		if len(trimmedBefore) == 0 && len(before) > 0 {
+			break
+		}
+		keptKinds := kinds[leadTrim : leadTrim+len(trimmedBefore)]
+		if strings.Count(string(keptKinds), "-") != strings.Count(string(kinds), "-") {
+			break
+		}
+
+		for _, offset := range fuzzOffsets(opts.Fuzz) {
+			start := h.oldStart - 1 + leadTrim + offset
+			if start < 0 || start+len(trimmedBefore) > len(lines) {
+				continue
+			}
+			if !matchContext(lines[start:start+len(trimmedBefore)], trimmedBefore, keptKinds, opts.IgnoreWhitespace) {
+				continue
+			}
+
+			out := buildResult(lines, h, start-leadTrim, trim, crlf)
+			return out, ApplyResult{
+				AppliedAt:      start + 1,
+				OffsetUsed:     offset,
+				ContextTrimmed: trim,
+			}, true
+		}
+	}
+
+	return lines, ApplyResult{
+		Rejected: true,
+		Reason:   "no matching context found within fuzz window",
+		OldStart: h.oldStart,
+		Context:  renderHunk(h),
+	}, false
+}
+
+// beforeLines extracts the hunk's "old file" content: context (' ') and
+// deletion ('-') lines, in order, which must be present verbatim for the
+// hunk to apply.
+func beforeLines(h hunk) []string {
+	var before []string
+	for _, c := range h.changes {
+		if c.kind == ' ' || c.kind == '-' {
+			before = append(before, c.content)
+		}
+	}
+	return before
+}
+
+// trimContext drops n lines from the ends of before (ceil(n/2) leading, the
+// rest trailing), returning the trimmed slice and how many leading lines
+// were removed (so the caller can adjust the search start position). It
+// does not look at line kinds; applyHunkFuzzy stops trimming before a
+// deletion line would be dropped.
+func trimContext(before []string, n int) ([]string, int) {
+	if n == 0 {
+		return before, 0
+	}
+
+	lead := 0
+	for lead < n/2+n%2 && lead < len(before) {
+		lead++
+	}
+	trail := n - lead
+	if trail > len(before)-lead {
+		trail = len(before) - lead
+	}
+	if lead+trail >= len(before) {
+		return nil, lead
+	}
+
+	return before[lead : len(before)-trail], lead
+}
+
+func fuzzOffsets(fuzz int) []int {
+	offsets := []int{0}
+	for d := 1; d <= fuzz; d++ {
+		offsets = append(offsets, d, -d)
+	}
+	return offsets
+}
+
+func matchContext(actual, expected []string, kinds []byte, ignoreWhitespace bool) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	for i := range actual {
+		// actual comes straight from strings.Split on the (possibly CRLF)
+		// original file; expected is always LF-normalized by parseHunks.
+		a := strings.TrimSuffix(actual[i], "\r")
+		e := expected[i]
+		if ignoreWhitespace && kinds[i] == ' ' {
+			if normalizeWhitespace(a) != normalizeWhitespace(e) {
+				return false
+			}
+		} else if a != e {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// buildResult reconstructs the file with the hunk applied at start
+// (0-based, before any leading-context trim adjustment), honoring trim
+// leading/trailing context lines that were dropped from matching but are
+// still emitted verbatim from the source file. When crlf is set, added
+// ('+') lines get a trailing \r too, so the file doesn't end up with a
+// mix of line endings.
+func buildResult(lines []string, h hunk, start int, trim int, crlf bool) []string {
+	var result []string
+	result = append(result, lines[:start]...)
+
+	lineIdx := start
+	for _, change := range h.changes {
+		switch change.kind {
+		case ' ':
+			if lineIdx < len(lines) {
+				result = append(result, lines[lineIdx])
+				lineIdx++
+			}
+		case '-':
+			lineIdx++
+		case '+':
+			content := change.content
+			if crlf && !strings.HasSuffix(content, "\r") {
+				content += "\r"
+			}
+			result = append(result, content)
+		}
+	}
+
+	result = append(result, lines[lineIdx:]...)
+	return result
+}
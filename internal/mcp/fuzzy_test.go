@@ -0,0 +1,57 @@
+package mcp
+
+import "testing"
+
+func TestApplyUnifiedDiffFuzzyExactOffset(t *testing.T) {
+	original := "line1\nline2\nline3\nline4\n"
+	diff := "@@ -2,1 +2,1 @@\n-line2\n+LINE2\n"
+
+	out, results, err := ApplyUnifiedDiffFuzzy(original, diff, ApplyUnifiedDiffOptions{Fuzz: 3})
+	if err != nil {
+		t.Fatalf("ApplyUnifiedDiffFuzzy() error = %v", err)
+	}
+	if results[0].Rejected {
+		t.Fatalf("expected hunk to apply, got rejected: %s", results[0].Reason)
+	}
+	want := "line1\nLINE2\nline3\nline4\n"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestApplyUnifiedDiffFuzzyOffsetDrift(t *testing.T) {
+	// Hunk claims line2 is at line 2, but the file has an extra line
+	// inserted above it, moving it to line 3 — a drifted snapshot the fuzz
+	// window should recover.
+	original := "preamble\nline1\nline2\nline3\n"
+	diff := "@@ -2,1 +2,1 @@\n-line2\n+LINE2\n"
+
+	out, results, err := ApplyUnifiedDiffFuzzy(original, diff, ApplyUnifiedDiffOptions{Fuzz: 2})
+	if err != nil {
+		t.Fatalf("ApplyUnifiedDiffFuzzy() error = %v", err)
+	}
+	if results[0].Rejected {
+		t.Fatalf("expected hunk to apply within fuzz window, got rejected: %s", results[0].Reason)
+	}
+	if results[0].OffsetUsed != 1 {
+		t.Errorf("OffsetUsed = %d, want 1", results[0].OffsetUsed)
+	}
+
+	want := "preamble\nline1\nLINE2\nline3\n"
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestApplyUnifiedDiffFuzzyRejectsUnmatched(t *testing.T) {
+	original := "completely\ndifferent\ncontent\n"
+	diff := "@@ -2,1 +2,1 @@\n-line2\n+LINE2\n"
+
+	_, results, err := ApplyUnifiedDiffFuzzy(original, diff, ApplyUnifiedDiffOptions{Fuzz: 1})
+	if err != nil {
+		t.Fatalf("ApplyUnifiedDiffFuzzy() error = %v", err)
+	}
+	if !results[0].Rejected {
+		t.Error("expected hunk to be rejected")
+	}
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHandleProjectWatchNotifiesOnChange(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	s := NewServer(tmpDir)
+
+	notified := make(chan *Notification, 8)
+	s.notify = func(n *Notification) { notified <- n }
+
+	result, err := s.handleProjectWatch(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("handleProjectWatch() error = %v", err)
+	}
+	if status, _ := result.(map[string]string)["status"]; status != "watching" {
+		t.Fatalf("expected status watching, got %v", result)
+	}
+	defer s.Shutdown()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "new.go"), []byte("package main"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	select {
+	case n := <-notified:
+		if n.Method != "project/fileChanged" {
+			t.Errorf("expected project/fileChanged notification, got %q", n.Method)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for project/fileChanged notification")
+	}
+}
+
+func TestHandleProjectWatchAlreadyWatching(t *testing.T) {
+	tmpDir := t.TempDir()
+	s := NewServer(tmpDir)
+	s.notify = func(n *Notification) {}
+
+	if _, err := s.handleProjectWatch(context.Background(), nil); err != nil {
+		t.Fatalf("handleProjectWatch() error = %v", err)
+	}
+	defer s.Shutdown()
+
+	result, err := s.handleProjectWatch(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("second handleProjectWatch() error = %v", err)
+	}
+	if status, _ := result.(map[string]string)["status"]; status != "already_watching" {
+		t.Errorf("expected status already_watching, got %v", result)
+	}
+}
+
+func TestHandleProjectUnwatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	s := NewServer(tmpDir)
+	s.notify = func(n *Notification) {}
+
+	if _, err := s.handleProjectWatch(context.Background(), nil); err != nil {
+		t.Fatalf("handleProjectWatch() error = %v", err)
+	}
+
+	if _, err := s.handleProjectUnwatch(context.Background(), nil); err != nil {
+		t.Fatalf("handleProjectUnwatch() error = %v", err)
+	}
+
+	if _, err := s.handleProjectUnwatch(context.Background(), nil); err == nil {
+		t.Fatal("expected an error unwatching an already-unwatched root")
+	}
+}
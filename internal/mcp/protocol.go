@@ -1,8 +1,11 @@
 package mcp
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 const (
@@ -23,6 +26,32 @@ type Response struct {
 	Error   *ErrorObject `json:"error,omitempty"`
 }
 
+// Notification is a server-initiated JSON-RPC message with no id, used for
+// events the client didn't explicitly request (e.g. project/fileChanged).
+type Notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+func NewNotification(method string, params interface{}) *Notification {
+	return &Notification{
+		JSONRPC: JSONRPCVersion,
+		Method:  method,
+		Params:  params,
+	}
+}
+
+// StreamResponse is the aggregate metadata carried in the final
+// SuccessResponse of a streamed result, letting the client verify it
+// reassembled every $/progress chunk correctly.
+type StreamResponse struct {
+	TotalBytes int64           `json:"totalBytes"`
+	SHA256     string          `json:"sha256"`
+	Chunks     int             `json:"chunks"`
+	Language   models.Language `json:"language,omitempty"`
+}
+
 type ErrorObject struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
@@ -56,6 +85,73 @@ func MarshalResponse(resp *Response) ([]byte, error) {
 	return json.Marshal(resp)
 }
 
+// ErrEmptyBatch is returned by ParseBatch (and ParseRequestBatch) when the
+// input is a JSON-RPC batch array with no elements, which the spec treats
+// as an Invalid Request rather than a parse error.
+var ErrEmptyBatch = fmt.Errorf("batch array must not be empty")
+
+// ParseBatch parses data as either a single JSON-RPC request object or a
+// JSON-RPC 2.0 batch (a top-level array of request objects), returning the
+// parsed requests and whether the input was in array form.
+func ParseBatch(data []byte) ([]*Request, bool, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, false, fmt.Errorf("empty request")
+	}
+
+	if trimmed[0] != '[' {
+		req, err := ParseRequest(trimmed)
+		if err != nil {
+			return nil, false, err
+		}
+		return []*Request{req}, false, nil
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(trimmed, &raw); err != nil {
+		return nil, true, fmt.Errorf("failed to parse batch: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, true, ErrEmptyBatch
+	}
+
+	reqs := make([]*Request, len(raw))
+	for i, r := range raw {
+		req, err := ParseRequest(r)
+		if err != nil {
+			return nil, true, err
+		}
+		reqs[i] = req
+	}
+	return reqs, true, nil
+}
+
+// ParseRequestBatch is an alias for ParseBatch kept under the name used by
+// JSON-RPC 2.0 batch-handling callers that don't care about the single-value
+// case.
+func ParseRequestBatch(data []byte) ([]*Request, bool, error) {
+	return ParseBatch(data)
+}
+
+// MarshalBatchResponse marshals resps as a JSON-RPC 2.0 batch array,
+// dropping any nil entries (the "response" to a notification, which per
+// spec must produce no output at all).
+func MarshalBatchResponse(resps []*Response) ([]byte, error) {
+	filtered := make([]*Response, 0, len(resps))
+	for _, r := range resps {
+		if r != nil {
+			filtered = append(filtered, r)
+		}
+	}
+	return json.Marshal(filtered)
+}
+
+// MarshalResponseBatch is an alias for MarshalBatchResponse kept under the
+// name used by JSON-RPC 2.0 batch-handling callers.
+func MarshalResponseBatch(resps []*Response) ([]byte, error) {
+	return MarshalBatchResponse(resps)
+}
+
 func SuccessResponse(id interface{}, result interface{}) *Response {
 	return &Response{
 		JSONRPC: JSONRPCVersion,
@@ -86,4 +182,6 @@ const (
 	TestFailure    = -32003
 	GitConflict    = -32004
 	LSPUnavailable = -32005
+	HashConflict   = -32006
+	FileIgnored    = -32007
 )
@@ -95,6 +95,7 @@ func TestErrorCodes(t *testing.T) {
 		{"TestFailure", TestFailure, -32003},
 		{"GitConflict", GitConflict, -32004},
 		{"LSPUnavailable", LSPUnavailable, -32005},
+		{"FileIgnored", FileIgnored, -32007},
 	}
 
 	for _, tt := range tests {
@@ -113,3 +114,91 @@ func TestErrorObject_Error(t *testing.T) {
 		t.Error("Error message should not be empty")
 	}
 }
+
+func TestParseBatch_SingleObject(t *testing.T) {
+	data := []byte(`{"jsonrpc":"2.0","id":1,"method":"read_file"}`)
+
+	reqs, isBatch, err := ParseBatch(data)
+	if err != nil {
+		t.Fatalf("ParseBatch failed: %v", err)
+	}
+	if isBatch {
+		t.Error("Expected isBatch = false for a single object")
+	}
+	if len(reqs) != 1 || reqs[0].Method != "read_file" {
+		t.Errorf("Expected one request for read_file, got %+v", reqs)
+	}
+}
+
+func TestParseBatch_Array(t *testing.T) {
+	data := []byte(`[{"jsonrpc":"2.0","id":1,"method":"read_file"},{"jsonrpc":"2.0","method":"notify_only"}]`)
+
+	reqs, isBatch, err := ParseBatch(data)
+	if err != nil {
+		t.Fatalf("ParseBatch failed: %v", err)
+	}
+	if !isBatch {
+		t.Error("Expected isBatch = true for a JSON array")
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(reqs))
+	}
+	if reqs[1].ID != nil {
+		t.Error("Expected the second request to be a notification with no id")
+	}
+}
+
+func TestStreamResponseMarshalsExpectedFields(t *testing.T) {
+	data, err := json.Marshal(StreamResponse{TotalBytes: 128, SHA256: "abc123", Chunks: 2})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded["totalBytes"] != float64(128) || decoded["sha256"] != "abc123" || decoded["chunks"] != float64(2) {
+		t.Errorf("unexpected fields: %+v", decoded)
+	}
+}
+
+func TestParseBatch_EmptyArray(t *testing.T) {
+	_, _, err := ParseBatch([]byte(`[]`))
+	if err != ErrEmptyBatch {
+		t.Errorf("expected ErrEmptyBatch, got %v", err)
+	}
+}
+
+func TestParseRequestBatch_Array(t *testing.T) {
+	data := []byte(`[{"jsonrpc":"2.0","id":1,"method":"read_file"}]`)
+
+	reqs, isBatch, err := ParseRequestBatch(data)
+	if err != nil {
+		t.Fatalf("ParseRequestBatch failed: %v", err)
+	}
+	if !isBatch || len(reqs) != 1 {
+		t.Errorf("expected a one-element batch, got isBatch=%v reqs=%+v", isBatch, reqs)
+	}
+}
+
+func TestMarshalBatchResponse_DropsNotificationEntries(t *testing.T) {
+	resps := []*Response{
+		SuccessResponse(1, "ok"),
+		nil,
+		ErrorResponse(2, InternalError, "boom", nil),
+	}
+
+	data, err := MarshalBatchResponse(resps)
+	if err != nil {
+		t.Fatalf("MarshalBatchResponse failed: %v", err)
+	}
+
+	var decoded []Response
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal batch response: %v", err)
+	}
+	if len(decoded) != 2 {
+		t.Errorf("Expected 2 responses (nil entry dropped), got %d", len(decoded))
+	}
+}
@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHandleReadFileRefusesIgnoredPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("*.secret\n"), 0644); err != nil {
+		t.Fatalf("failed to write .gitignore: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "creds.secret"), []byte("sk-test"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "app.go"), []byte("package main"), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	s := NewServer(tmpDir)
+
+	params, _ := json.Marshal(map[string]string{"path": "creds.secret"})
+	if _, err := s.handleReadFile(context.Background(), params); err == nil {
+		t.Fatal("expected an error for a gitignored path, got nil")
+	} else if errObj, ok := err.(*ErrorObject); !ok || errObj.Code != FileIgnored {
+		t.Errorf("expected FileIgnored error, got %v", err)
+	}
+
+	params, _ = json.Marshal(map[string]string{"path": "app.go"})
+	if _, err := s.handleReadFile(context.Background(), params); err != nil {
+		t.Errorf("expected no error for a non-ignored path, got %v", err)
+	}
+}
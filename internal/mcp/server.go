@@ -2,32 +2,57 @@ package mcp
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/alexkarsten/reducto/internal/cache"
 	"github.com/alexkarsten/reducto/internal/git"
+	"github.com/alexkarsten/reducto/internal/linter"
 	"github.com/alexkarsten/reducto/internal/lsp"
 	"github.com/alexkarsten/reducto/internal/runner"
+	"github.com/alexkarsten/reducto/internal/treesitter"
 	"github.com/alexkarsten/reducto/internal/walker"
 	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 type Server struct {
-	rootDir string
-	walker  *walker.Walker
-	runner  *runner.Runner
-	gitMgr  *git.Manager
-	lspMgr  *lsp.Manager
+	rootDir       string
+	walker        *walker.Walker
+	ignoreMatcher *walker.Matcher
+	runner        *runner.Runner
+	gitMgr        *git.Manager
+	lspMgr        *lsp.Manager
+	ts            *treesitter.Parser
+	cache         *cache.Cache
+	linter        *linter.Linter
 
 	mu       sync.RWMutex
 	sessions map[string]*Session
+
+	cancelMu sync.Mutex
+	cancels  map[string]context.CancelFunc
+
+	notify func(*Notification)
+
+	watchMu sync.Mutex
+	watches map[string]context.CancelFunc
+
+	// reqSem bounds how many requests run at once across the server:
+	// batch elements, successive pipelined lines, or both at the same
+	// time, since they share this one semaphore.
+	reqSem chan struct{}
 }
 
 type Session struct {
@@ -39,15 +64,36 @@ type Session struct {
 
 func NewServer(rootDir string) *Server {
 	return &Server{
-		rootDir:  rootDir,
-		walker:   walker.New(nil, nil),
-		runner:   runner.New(rootDir),
-		gitMgr:   git.NewManager(rootDir),
-		lspMgr:   lsp.NewManager(),
-		sessions: make(map[string]*Session),
+		rootDir:       rootDir,
+		walker:        walker.New(nil, nil),
+		ignoreMatcher: walker.NewWithGitignore(rootDir),
+		runner:        runner.New(rootDir),
+		gitMgr:        git.NewManager(rootDir),
+		lspMgr:        lsp.NewManager(),
+		ts:            treesitter.New(),
+		cache:         cache.New(),
+		linter:        linter.New(rootDir),
+		sessions:      make(map[string]*Session),
+		cancels:       make(map[string]context.CancelFunc),
+		watches:       make(map[string]context.CancelFunc),
+		reqSem:        make(chan struct{}, maxConcurrentRequests),
 	}
 }
 
+// ApplyConfig re-reads cache-related settings from cfg into the server's
+// LSP manager, so a subscriber to a live config.Loader can apply a hot
+// reload without restarting the server.
+func (s *Server) ApplyConfig(cfg *models.Config) {
+	s.lspMgr.ApplyConfig(cfg)
+}
+
+// WatchCache starts a filesystem watch on rootDir and keeps the symbol/
+// complexity cache in sync with it, so warm get_symbols, get_complexity,
+// and list_files requests don't need to re-read and re-parse from disk.
+func (s *Server) WatchCache(ctx context.Context) error {
+	return s.cache.Run(ctx, s.walker, s.rootDir)
+}
+
 func (s *Server) InitLSP(ctx context.Context) error {
 	languages := []string{}
 	hasGo := false
@@ -102,40 +148,227 @@ func (s *Server) InitLSP(ctx context.Context) error {
 	return s.lspMgr.Initialize(ctx, rootURI, languages)
 }
 
+// maxConcurrentRequests bounds how many requests run at once across the
+// whole server: batch elements (via HandleBatch) and successive pipelined
+// lines (via Start) draw from the same s.reqSem, so a flood of either kind
+// — or both together — can't spawn unbounded goroutines or subprocesses.
+const maxConcurrentRequests = 8
+
+// streamChunkSize is how much of read_file's streamed content is sent per
+// $/progress notification; list_files streams one file entry per
+// notification instead.
+const streamChunkSize = 64 * 1024
+
+// Start reads one JSON-RPC value per line from stdin and dispatches it.
+// A line may be a single request object or a JSON-RPC 2.0 batch array; each
+// element of a batch, and each successive line, is dispatched concurrently
+// through a bounded worker pool so a slow call like run_tests doesn't block
+// other pipelined requests. A batch line is answered as a whole before the
+// next line is read, so a slow element of a batch holds up the lines after
+// it. Requests with no "id" are notifications and
+// produce no response. Writes to stdout are serialized so concurrent
+// responses can't interleave.
 func (s *Server) Start(ctx context.Context, stdin io.Reader, stdout io.Writer) error {
 	scanner := bufio.NewScanner(stdin)
 	scanner.Buffer(make([]byte, 10*1024*1024), 10*1024*1024)
 
 	encoder := json.NewEncoder(stdout)
+	var encMu sync.Mutex
+	write := func(v interface{}) {
+		if v == nil {
+			return
+		}
+		encMu.Lock()
+		encoder.Encode(v)
+		encMu.Unlock()
+	}
+	writeRaw := func(line []byte) {
+		encMu.Lock()
+		stdout.Write(append(line, '\n'))
+		encMu.Unlock()
+	}
+
+	s.notify = func(n *Notification) { write(n) }
+
+	var wg sync.WaitGroup
 
 	for scanner.Scan() {
 		select {
 		case <-ctx.Done():
+			wg.Wait()
 			return ctx.Err()
 		default:
 		}
 
-		line := scanner.Bytes()
+		line := bytes.TrimSpace(scanner.Bytes())
 		if len(line) == 0 {
 			continue
 		}
 
-		req, err := ParseRequest(line)
-		if err != nil {
-			encoder.Encode(ErrorResponse(nil, ParseError, err.Error(), nil))
+		if line[0] == '[' {
+			reqs, _, err := ParseRequestBatch(line)
+			if err == ErrEmptyBatch {
+				write(ErrorResponse(nil, InvalidRequest, err.Error(), nil))
+				continue
+			}
+			if err != nil {
+				write(ErrorResponse(nil, ParseError, err.Error(), nil))
+				continue
+			}
+
+			results := s.HandleBatch(ctx, reqs)
+
+			hasResponse := false
+			for _, r := range results {
+				if r != nil {
+					hasResponse = true
+					break
+				}
+			}
+			if hasResponse {
+				batch, err := MarshalResponseBatch(results)
+				if err != nil {
+					write(ErrorResponse(nil, InternalError, err.Error(), nil))
+					continue
+				}
+				writeRaw(batch)
+			}
 			continue
 		}
 
-		resp := s.handleRequest(ctx, req)
-		if resp != nil {
-			encoder.Encode(resp)
-		}
+		raw := append(json.RawMessage(nil), line...)
+		wg.Add(1)
+		s.reqSem <- struct{}{}
+		go func(raw json.RawMessage) {
+			defer wg.Done()
+			defer func() { <-s.reqSem }()
+			write(s.dispatch(ctx, raw))
+		}(raw)
 	}
 
+	wg.Wait()
 	return scanner.Err()
 }
 
+// dispatch parses and runs a single JSON-RPC value, returning nil when no
+// response should be written (notifications, and $/cancelRequest itself).
+func (s *Server) dispatch(ctx context.Context, raw json.RawMessage) *Response {
+	req, err := ParseRequest(raw)
+	if err != nil {
+		return ErrorResponse(nil, ParseError, err.Error(), nil)
+	}
+	return s.dispatchParsed(ctx, req)
+}
+
+// HandleBatch runs each of reqs through dispatchParsed concurrently, bounded
+// by the same s.reqSem as Start's pipelined single-line dispatch, preserving
+// reqs' order in the returned slice (a nil entry means that request was a
+// notification and produced no response). A handler that panics is isolated
+// to its own request: it's recovered and turned into an InternalError
+// response rather than losing the rest of the batch.
+func (s *Server) HandleBatch(ctx context.Context, reqs []*Request) []*Response {
+	results := make([]*Response, len(reqs))
+	var wg sync.WaitGroup
+
+	for i, req := range reqs {
+		wg.Add(1)
+		s.reqSem <- struct{}{}
+		go func(i int, req *Request) {
+			defer wg.Done()
+			defer func() { <-s.reqSem }()
+			results[i] = s.dispatchRecovered(ctx, req)
+		}(i, req)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// dispatchRecovered wraps dispatchParsed with panic recovery so one
+// misbehaving request can't take down the rest of a batch.
+func (s *Server) dispatchRecovered(ctx context.Context, req *Request) (resp *Response) {
+	defer func() {
+		if r := recover(); r != nil {
+			if req.ID == nil {
+				resp = nil
+				return
+			}
+			resp = ErrorResponse(req.ID, InternalError, "Internal error", fmt.Sprint(r))
+		}
+	}()
+	return s.dispatchParsed(ctx, req)
+}
+
+// dispatchParsed runs an already-parsed request, returning nil when no
+// response should be written (notifications, and $/cancelRequest itself).
+// Each request with an id gets its own cancellable context, registered so
+// a later $/cancelRequest for that id can unblock it.
+func (s *Server) dispatchParsed(ctx context.Context, req *Request) *Response {
+	if req.Method == "$/cancelRequest" {
+		s.cancelRequest(req.Params)
+		return nil
+	}
+
+	reqCtx := ctx
+	if req.ID != nil {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithCancel(ctx)
+		s.registerCancel(req.ID, cancel)
+		defer s.unregisterCancel(req.ID)
+	}
+
+	resp := s.handleRequest(reqCtx, req)
+	if req.ID == nil {
+		return nil
+	}
+	return resp
+}
+
+// cancelRequest looks up the CancelFunc registered for params.id and
+// invokes it, per the LSP/JSON-RPC $/cancelRequest convention. Unknown or
+// already-finished ids are silently ignored.
+func (s *Server) cancelRequest(params json.RawMessage) {
+	var input struct {
+		ID interface{} `json:"id"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return
+	}
+
+	s.cancelMu.Lock()
+	cancel, ok := s.cancels[fmt.Sprint(input.ID)]
+	s.cancelMu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+func (s *Server) registerCancel(id interface{}, cancel context.CancelFunc) {
+	s.cancelMu.Lock()
+	s.cancels[fmt.Sprint(id)] = cancel
+	s.cancelMu.Unlock()
+}
+
+func (s *Server) unregisterCancel(id interface{}) {
+	s.cancelMu.Lock()
+	delete(s.cancels, fmt.Sprint(id))
+	s.cancelMu.Unlock()
+}
+
 func (s *Server) handleRequest(ctx context.Context, req *Request) *Response {
+	if wantsStream(req.Params) {
+		if streamHandler, ok := s.getStreamHandler(req.Method); ok {
+			result, err := streamHandler(ctx, req.ID, req.Params)
+			if err != nil {
+				if rpcErr, ok := err.(*ErrorObject); ok {
+					return ErrorResponse(req.ID, rpcErr.Code, rpcErr.Message, rpcErr.Data)
+				}
+				return ErrorResponse(req.ID, InternalError, err.Error(), nil)
+			}
+			return SuccessResponse(req.ID, result)
+		}
+	}
+
 	handler, ok := s.getHandler(req.Method)
 	if !ok {
 		return ErrorResponse(req.ID, MethodNotFound, "Method not found", req.Method)
@@ -162,25 +395,87 @@ func (s *Server) getHandler(method string) (HandlerFunc, bool) {
 		"get_symbols":     s.handleGetSymbols,
 		"get_ast":         s.handleGetAST,
 		"find_references": s.handleFindReferences,
+		"hover":           s.handleHover,
+		"definition":      s.handleDefinition,
 		"apply_diff":      s.handleApplyDiff,
+		"apply_patch":     s.handleApplyPatch,
+		"session_end":     s.handleSessionEnd,
 		"run_tests":       s.handleRunTests,
 		"git_checkpoint":  s.handleGitCheckpoint,
 		"git_rollback":    s.handleGitRollback,
 		"list_files":      s.handleListFiles,
 		"get_complexity":  s.handleGetComplexity,
+		"run_lint":        s.handleRunLint,
+		"git_blame":       s.handleGitBlame,
+		"git_log":         s.handleGitLog,
+		"git_diff":        s.handleGitDiff,
+		"git_stats":       s.handleGitStats,
+		"project/watch":   s.handleProjectWatch,
+		"project/unwatch": s.handleProjectUnwatch,
 	}
 	h, ok := handlers[method]
 	return h, ok
 }
 
+// StreamingHandlerFunc is a HandlerFunc variant for methods that can relay
+// their result as a sequence of $/progress notifications instead of one
+// buffered reply, which needs the original request's id to tag each chunk.
+type StreamingHandlerFunc func(ctx context.Context, id interface{}, params json.RawMessage) (interface{}, error)
+
+// getStreamHandler returns the streaming variant of method, if it has one.
+// Methods with no streaming variant are handled normally by getHandler, so
+// a client that opts into streaming against a method that doesn't support
+// it falls back transparently.
+func (s *Server) getStreamHandler(method string) (StreamingHandlerFunc, bool) {
+	handlers := map[string]StreamingHandlerFunc{
+		"read_file":  s.handleReadFileStream,
+		"list_files": s.handleListFilesStream,
+	}
+	h, ok := handlers[method]
+	return h, ok
+}
+
+// wantsStream reports whether params opts into streaming via the
+// JSON-RPC "_meta" convention: {"_meta": {"stream": true}}.
+func wantsStream(params json.RawMessage) bool {
+	var input struct {
+		Meta struct {
+			Stream bool `json:"stream"`
+		} `json:"_meta"`
+	}
+	json.Unmarshal(params, &input)
+	return input.Meta.Stream
+}
+
+// SendChunk emits one $/progress notification carrying a base64-encoded
+// slice of a streamed result, tagged with id and seq so the client can
+// reassemble chunks in order; last marks the final chunk for id. It's a
+// no-op before Start has wired up s.notify (e.g. if a handler is called
+// directly in a test).
+func (s *Server) SendChunk(id interface{}, seq int, data []byte, last bool) {
+	if s.notify == nil {
+		return
+	}
+	s.notify(NewNotification("$/progress", map[string]interface{}{
+		"id":    id,
+		"seq":   seq,
+		"chunk": base64.StdEncoding.EncodeToString(data),
+		"done":  last,
+	}))
+}
+
 func (s *Server) handleInitialize(ctx context.Context, params json.RawMessage) (interface{}, error) {
 	return map[string]interface{}{
 		"status":  "initialized",
 		"version": "0.1.0",
 		"tools": []string{
 			"read_file", "get_symbols", "get_ast", "find_references",
-			"apply_diff", "run_tests", "git_checkpoint", "git_rollback",
-			"list_files", "get_complexity",
+			"hover", "definition",
+			"apply_diff", "apply_patch", "session_end",
+			"run_tests", "git_checkpoint", "git_rollback",
+			"list_files", "get_complexity", "run_lint",
+			"git_blame", "git_log", "git_diff", "git_stats",
+			"project/watch", "project/unwatch",
 		},
 	}, nil
 }
@@ -198,13 +493,17 @@ func (s *Server) handleReadFile(ctx context.Context, params json.RawMessage) (in
 	}
 
 	fullPath := filepath.Join(s.rootDir, input.Path)
+	if ignored, rule := s.ignoreMatcher.Matches(fullPath); ignored {
+		return nil, NewError(FileIgnored, "File is excluded by gitignore rules", rule)
+	}
+
 	content, err := os.ReadFile(fullPath)
 	if err != nil {
 		return nil, NewError(FileNotFound, "Failed to read file", err.Error())
 	}
 
 	hash := sha256.Sum256(content)
-	lang := s.walker.DetectLanguage(input.Path)
+	lang := s.walker.DetectLanguageFromContent(input.Path, content)
 
 	return map[string]interface{}{
 		"path":     input.Path,
@@ -214,263 +513,225 @@ func (s *Server) handleReadFile(ctx context.Context, params json.RawMessage) (in
 	}, nil
 }
 
-func (s *Server) handleGetSymbols(ctx context.Context, params json.RawMessage) (interface{}, error) {
+// handleReadFileStream is read_file's streaming variant: it sends the
+// file's content as a sequence of streamChunkSize $/progress notifications
+// instead of a single buffered reply, so a multi-MB file never needs its
+// whole body held in memory at once. The final SuccessResponse carries a
+// StreamResponse the client can use to verify it reassembled every chunk.
+func (s *Server) handleReadFileStream(ctx context.Context, id interface{}, params json.RawMessage) (interface{}, error) {
 	var input struct {
-		Path    string `json:"path"`
-		Content string `json:"content,omitempty"`
+		Path string `json:"path"`
 	}
 	if err := json.Unmarshal(params, &input); err != nil {
 		return nil, NewError(InvalidParams, "Invalid params", err.Error())
 	}
 
-	var content string
-	if input.Content != "" {
-		content = input.Content
-	} else {
-		fullPath := filepath.Join(s.rootDir, input.Path)
-		data, err := os.ReadFile(fullPath)
-		if err != nil {
-			return nil, NewError(FileNotFound, "Failed to read file", err.Error())
-		}
-		content = string(data)
+	fullPath := filepath.Join(s.rootDir, input.Path)
+	if ignored, rule := s.ignoreMatcher.Matches(fullPath); ignored {
+		return nil, NewError(FileIgnored, "File is excluded by gitignore rules", rule)
 	}
 
-	lang := s.walker.DetectLanguage(input.Path)
-
-	symbols := s.extractSymbols(content, input.Path, lang)
-
-	return map[string]interface{}{
-		"path":    input.Path,
-		"symbols": symbols,
-	}, nil
-}
-
-func (s *Server) extractSymbols(content, path string, lang models.Language) []models.Symbol {
-	var symbols []models.Symbol
-	lines := strings.Split(content, "\n")
-
-	switch lang {
-	case models.LanguagePython:
-		symbols = s.extractPythonSymbols(lines, path)
-	case models.LanguageJavaScript, models.LanguageTypeScript:
-		symbols = s.extractJSSymbols(lines, path)
-	case models.LanguageGo:
-		symbols = s.extractGoSymbols(lines, path)
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return nil, NewError(FileNotFound, "Failed to read file", err.Error())
 	}
+	defer f.Close()
 
-	return symbols
-}
-
-func (s *Server) extractPythonSymbols(lines []string, path string) []models.Symbol {
-	var symbols []models.Symbol
-	var currentClass string
-
-	for i, line := range lines {
-		stripped := strings.TrimSpace(line)
-
-		if strings.HasPrefix(stripped, "def ") || strings.HasPrefix(stripped, "async def ") {
-			name := s.extractFunctionName(stripped)
-			symbolType := "function"
-			if currentClass != "" {
-				symbolType = "method"
-			}
-			symbols = append(symbols, models.Symbol{
-				Name:      name,
-				Type:      symbolType,
-				File:      path,
-				StartLine: i + 1,
-				EndLine:   s.findPythonBlockEnd(lines, i),
-			})
-		} else if strings.HasPrefix(stripped, "class ") {
-			name := s.extractClassName(stripped)
-			currentClass = name
-			symbols = append(symbols, models.Symbol{
-				Name:      name,
-				Type:      "class",
-				File:      path,
-				StartLine: i + 1,
-				EndLine:   s.findPythonBlockEnd(lines, i),
-			})
-		} else if stripped != "" && !strings.HasPrefix(stripped, "#") && !strings.HasPrefix(stripped, "@") {
-			if strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t") {
-			} else {
-				currentClass = ""
-			}
-		}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, NewError(FileNotFound, "Failed to read file", err.Error())
 	}
+	size := info.Size()
+
+	hasher := sha256.New()
+	buf := make([]byte, streamChunkSize)
+	var total int64
+	var lang models.Language
+	seq := 0
+
+	for {
+		n, readErr := f.Read(buf)
+		if readErr != nil && readErr != io.EOF {
+			return nil, NewError(FileNotFound, "Failed to read file", readErr.Error())
+		}
 
-	return symbols
-}
-
-func (s *Server) extractJSSymbols(lines []string, path string) []models.Symbol {
-	var symbols []models.Symbol
-
-	for i, line := range lines {
-		stripped := strings.TrimSpace(line)
-
-		if strings.Contains(stripped, "function ") || strings.Contains(stripped, "=>") {
-			name := s.extractJSFunctionName(stripped)
-			if name != "" {
-				symbols = append(symbols, models.Symbol{
-					Name:      name,
-					Type:      "function",
-					File:      path,
-					StartLine: i + 1,
-					EndLine:   s.findBraceBlockEnd(lines, i),
-				})
+		if n > 0 {
+			hasher.Write(buf[:n])
+			total += int64(n)
+			if seq == 0 {
+				lang = s.walker.DetectLanguageFromContent(input.Path, buf[:n])
 			}
-		} else if strings.HasPrefix(stripped, "class ") {
-			name := s.extractClassName(stripped)
-			symbols = append(symbols, models.Symbol{
-				Name:      name,
-				Type:      "class",
-				File:      path,
-				StartLine: i + 1,
-				EndLine:   s.findBraceBlockEnd(lines, i),
-			})
 		}
-	}
-
-	return symbols
-}
-
-func (s *Server) extractGoSymbols(lines []string, path string) []models.Symbol {
-	var symbols []models.Symbol
 
-	for i, line := range lines {
-		stripped := strings.TrimSpace(line)
+		// Knowing size up front lets the chunk that reaches it carry
+		// done:true itself, instead of waiting for a trailing, empty
+		// io.EOF read that would otherwise show up as a spurious extra
+		// notification for files that fit in a single Read.
+		last := total >= size || readErr == io.EOF
+		s.SendChunk(id, seq, buf[:n], last)
+		seq++
 
-		if strings.HasPrefix(stripped, "func ") {
-			name := s.extractGoFunctionName(stripped)
-			symbols = append(symbols, models.Symbol{
-				Name:      name,
-				Type:      "function",
-				File:      path,
-				StartLine: i + 1,
-				EndLine:   s.findBraceBlockEnd(lines, i),
-			})
-		} else if strings.HasPrefix(stripped, "type ") && strings.Contains(stripped, " struct") {
-			name := s.extractGoTypeName(stripped)
-			symbols = append(symbols, models.Symbol{
-				Name:      name,
-				Type:      "struct",
-				File:      path,
-				StartLine: i + 1,
-				EndLine:   s.findBraceBlockEnd(lines, i),
-			})
+		if last {
+			break
 		}
 	}
 
-	return symbols
+	return StreamResponse{
+		TotalBytes: total,
+		SHA256:     hex.EncodeToString(hasher.Sum(nil)),
+		Chunks:     seq,
+		Language:   lang,
+	}, nil
 }
 
-func (s *Server) extractFunctionName(line string) string {
-	line = strings.TrimPrefix(line, "async ")
-	line = strings.TrimPrefix(line, "def ")
+func (s *Server) handleGetSymbols(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path    string `json:"path"`
+		Content string `json:"content,omitempty"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, NewError(InvalidParams, "Invalid params", err.Error())
+	}
 
-	idx := strings.Index(line, "(")
-	if idx > 0 {
-		return strings.TrimSpace(line[:idx])
+	content, err := s.resolveContent(input.Path, input.Content)
+	if err != nil {
+		return nil, err
 	}
-	return strings.Fields(line)[0]
-}
 
-func (s *Server) extractClassName(line string) string {
-	line = strings.TrimPrefix(line, "class ")
+	lang := s.walker.DetectLanguage(input.Path)
 
-	for _, delim := range []string{"(", ":", "[", "{"} {
-		if idx := strings.Index(line, delim); idx > 0 {
-			return strings.TrimSpace(line[:idx])
-		}
+	symbols, err := s.symbolsForFile(ctx, input.Path, content, lang)
+	if err != nil {
+		return nil, NewError(ParseFailure, "Failed to extract symbols", err.Error())
 	}
-	return strings.TrimSpace(line)
+
+	return map[string]interface{}{
+		"path":    input.Path,
+		"symbols": symbols,
+	}, nil
 }
 
-func (s *Server) extractJSFunctionName(line string) string {
-	patterns := []string{"function ", "const ", "let ", "var ", "async "}
-	for _, p := range patterns {
-		if idx := strings.Index(line, p); idx >= 0 {
-			rest := line[idx+len(p):]
-			if nameIdx := strings.Index(rest, "("); nameIdx > 0 {
-				name := strings.TrimSpace(rest[:nameIdx])
-				name = strings.TrimSuffix(name, "=")
-				name = strings.TrimSpace(name)
-				return name
+// symbolsForFile prefers an LSP client's textDocument/documentSymbol when
+// one is registered for lang, since it understands the language's full
+// grammar and type information; it falls back to the Tree-sitter extractor
+// when no client is registered or the LSP call fails (e.g. the server
+// hasn't finished indexing yet).
+func (s *Server) symbolsForFile(ctx context.Context, path, content string, lang models.Language) ([]models.Symbol, error) {
+	if langKey, ok := lspLanguageKey(lang); ok {
+		if client := s.lspMgr.GetClient(langKey); client != nil {
+			absPath, err := filepath.Abs(filepath.Join(s.rootDir, path))
+			if err == nil {
+				uri := "file://" + absPath
+				if symbols, err := client.DocumentSymbol(ctx, uri); err == nil {
+					return symbols, nil
+				}
 			}
 		}
 	}
-	return ""
+
+	return s.extractSymbols(ctx, content, path, lang)
 }
 
-func (s *Server) extractGoFunctionName(line string) string {
-	line = strings.TrimPrefix(line, "func ")
+// lspLanguageKey maps a models.Language to the string key lsp.Manager
+// registers clients under (see Server.InitLSP), or ok=false if reducto has
+// no LSP client for that language.
+func lspLanguageKey(lang models.Language) (string, bool) {
+	switch lang {
+	case models.LanguageGo:
+		return "go", true
+	case models.LanguagePython:
+		return "python", true
+	case models.LanguageTypeScript, models.LanguageJavaScript:
+		return "typescript", true
+	default:
+		return "", false
+	}
+}
 
-	if strings.HasPrefix(line, "(") {
-		closeIdx := strings.Index(line, ")")
-		if closeIdx > 0 {
-			line = line[closeIdx+1:]
-		}
+// extractSymbols parses content with Tree-sitter and walks the resulting
+// AST via language-specific queries, so decorators, multi-line signatures,
+// generics, and arrow-function/object-property assignments are all found
+// correctly instead of approximated by line scanning. Results are cached
+// by content hash, so re-parsing is skipped as long as the watcher hasn't
+// seen the file change since.
+func (s *Server) extractSymbols(ctx context.Context, content, path string, lang models.Language) ([]models.Symbol, error) {
+	hash := contentHash(content)
+	if symbols, ok := s.cache.Symbols(path, hash); ok {
+		return symbols, nil
 	}
 
-	if idx := strings.Index(line, "("); idx > 0 {
-		return strings.TrimSpace(line[:idx])
+	tree, err := s.ts.Parse(ctx, lang, path, content)
+	if err != nil {
+		return nil, err
+	}
+	symbols, err := treesitter.ExtractSymbols(tree, []byte(content), lang, path)
+	if err != nil {
+		return nil, err
 	}
-	return strings.TrimSpace(line)
+
+	s.cache.PutSymbols(path, hash, symbols)
+	return symbols, nil
 }
 
-func (s *Server) extractGoTypeName(line string) string {
-	line = strings.TrimPrefix(line, "type ")
-	if idx := strings.Index(line, " struct"); idx > 0 {
-		return strings.TrimSpace(line[:idx])
-	}
-	return ""
+// contentHash is the cache key used to tell whether a file's content has
+// changed since it was last parsed.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
 }
 
-func (s *Server) findPythonBlockEnd(lines []string, start int) int {
-	if start >= len(lines) {
-		return len(lines)
+// handleGetAST returns a compact JSON projection of content's Tree-sitter
+// parse tree (node type, source span, named children), bounded to
+// max_depth levels so large files don't produce an unusably large response.
+// max_depth defaults to -1 (unlimited) when omitted.
+func (s *Server) handleGetAST(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path     string `json:"path"`
+		Content  string `json:"content,omitempty"`
+		MaxDepth *int   `json:"max_depth,omitempty"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, NewError(InvalidParams, "Invalid params", err.Error())
 	}
 
-	startIndent := len(lines[start]) - len(strings.TrimLeft(lines[start], " \t"))
-
-	for i := start + 1; i < len(lines); i++ {
-		line := lines[i]
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		currentIndent := len(line) - len(strings.TrimLeft(line, " \t"))
-		if currentIndent <= startIndent {
-			return i
-		}
+	content, err := s.resolveContent(input.Path, input.Content)
+	if err != nil {
+		return nil, err
 	}
 
-	return len(lines)
-}
+	lang := s.walker.DetectLanguage(input.Path)
 
-func (s *Server) findBraceBlockEnd(lines []string, start int) int {
-	braceCount := 0
-	started := false
+	maxDepth := -1
+	if input.MaxDepth != nil {
+		maxDepth = *input.MaxDepth
+	}
 
-	for i := start; i < len(lines); i++ {
-		for _, ch := range lines[i] {
-			if ch == '{' {
-				braceCount++
-				started = true
-			} else if ch == '}' {
-				braceCount--
-				if started && braceCount == 0 {
-					return i + 1
-				}
-			}
-		}
+	tree, err := s.ts.Parse(ctx, lang, input.Path, content)
+	if err != nil {
+		return nil, NewError(ParseFailure, "Failed to parse AST", err.Error())
 	}
 
-	return len(lines)
+	root := treesitter.ToJSON(tree.RootNode(), []byte(content), nil, maxDepth)
+
+	return map[string]interface{}{
+		"path": input.Path,
+		"ast":  root,
+	}, nil
 }
 
-func (s *Server) handleGetAST(ctx context.Context, params json.RawMessage) (interface{}, error) {
-	return nil, NewError(InternalError, "AST extraction not yet implemented with Tree-sitter", nil)
+// resolveContent returns content verbatim if provided, else reads path
+// relative to rootDir — the same input shape get_symbols/get_ast/
+// get_complexity all accept.
+func (s *Server) resolveContent(path, content string) (string, error) {
+	if content != "" {
+		return content, nil
+	}
+	fullPath := filepath.Join(s.rootDir, path)
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return "", NewError(FileNotFound, "Failed to read file", err.Error())
+	}
+	return string(data), nil
 }
 
 func (s *Server) handleFindReferences(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -491,15 +752,8 @@ func (s *Server) handleFindReferences(ctx context.Context, params json.RawMessag
 	}
 
 	lang := s.walker.DetectLanguage(input.Path)
-	var language string
-	switch lang {
-	case models.LanguageGo:
-		language = "go"
-	case models.LanguagePython:
-		language = "python"
-	case models.LanguageTypeScript, models.LanguageJavaScript:
-		language = "typescript"
-	default:
+	language, ok := lspLanguageKey(lang)
+	if !ok {
 		return map[string]interface{}{
 			"references": []interface{}{},
 		}, nil
@@ -540,6 +794,96 @@ func (s *Server) handleFindReferences(ctx context.Context, params json.RawMessag
 	}, nil
 }
 
+func (s *Server) handleHover(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path   string `json:"path"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, NewError(InvalidParams, "Invalid params", err.Error())
+	}
+
+	if input.Line == 0 {
+		input.Line = 1
+	}
+
+	lang := s.walker.DetectLanguage(input.Path)
+	language, ok := lspLanguageKey(lang)
+	if !ok {
+		return map[string]interface{}{"contents": ""}, nil
+	}
+
+	absPath, err := filepath.Abs(filepath.Join(s.rootDir, input.Path))
+	if err != nil {
+		return nil, NewError(InternalError, "Failed to resolve path", err.Error())
+	}
+	uri := "file://" + absPath
+
+	contents, err := s.lspMgr.Hover(ctx, language, uri, input.Line, input.Column)
+	if err != nil {
+		return map[string]interface{}{
+			"contents": "",
+			"error":    err.Error(),
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"contents": contents,
+	}, nil
+}
+
+func (s *Server) handleDefinition(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path   string `json:"path"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, NewError(InvalidParams, "Invalid params", err.Error())
+	}
+
+	if input.Line == 0 {
+		input.Line = 1
+	}
+
+	lang := s.walker.DetectLanguage(input.Path)
+	language, ok := lspLanguageKey(lang)
+	if !ok {
+		return map[string]interface{}{"definition": nil}, nil
+	}
+
+	absPath, err := filepath.Abs(filepath.Join(s.rootDir, input.Path))
+	if err != nil {
+		return nil, NewError(InternalError, "Failed to resolve path", err.Error())
+	}
+	uri := "file://" + absPath
+
+	def, err := s.lspMgr.GoToDefinition(ctx, language, uri, input.Line, input.Column)
+	if err != nil {
+		return map[string]interface{}{
+			"definition": nil,
+			"error":      err.Error(),
+		}, nil
+	}
+
+	relPath := def.URI
+	if strings.HasPrefix(relPath, "file://") {
+		relPath = strings.TrimPrefix(relPath, "file://")
+		if rel, err := filepath.Rel(s.rootDir, relPath); err == nil {
+			relPath = rel
+		}
+	}
+
+	return map[string]interface{}{
+		"definition": map[string]interface{}{
+			"file":   relPath,
+			"line":   def.Line,
+			"column": def.Column,
+		},
+	}, nil
+}
+
 func (s *Server) handleApplyDiff(ctx context.Context, params json.RawMessage) (interface{}, error) {
 	var input struct {
 		Path      string `json:"path"`
@@ -557,10 +901,13 @@ func (s *Server) handleApplyDiff(ctx context.Context, params json.RawMessage) (i
 		return nil, NewError(FileNotFound, "Failed to read file", err.Error())
 	}
 
-	newContent, err := ApplyUnifiedDiff(string(content), input.Diff)
+	newContent, rejects, err := ApplyUnifiedDiff(string(content), input.Diff)
 	if err != nil {
 		return nil, NewError(ParseFailure, "Failed to apply diff", err.Error())
 	}
+	if len(rejects) > 0 {
+		return nil, NewError(ParseFailure, "Diff did not match file content", rejectSummary(rejects))
+	}
 
 	if err := os.WriteFile(fullPath, []byte(newContent), 0644); err != nil {
 		return nil, NewError(InternalError, "Failed to write file", err.Error())
@@ -573,7 +920,7 @@ func (s *Server) handleApplyDiff(ctx context.Context, params json.RawMessage) (i
 }
 
 func (s *Server) handleRunTests(ctx context.Context, params json.RawMessage) (interface{}, error) {
-	result, err := s.runner.RunTests()
+	result, err := s.runner.RunTests(ctx)
 	if err != nil {
 		return nil, NewError(InternalError, "Failed to run tests", err.Error())
 	}
@@ -612,6 +959,24 @@ func (s *Server) handleGitCheckpoint(ctx context.Context, params json.RawMessage
 }
 
 func (s *Server) handleGitRollback(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		SessionID string `json:"session_id,omitempty"`
+	}
+	json.Unmarshal(params, &input)
+
+	if input.SessionID != "" {
+		s.mu.RLock()
+		session, ok := s.sessions[input.SessionID]
+		s.mu.RUnlock()
+		if !ok {
+			return nil, NewError(InvalidParams, "Unknown session", input.SessionID)
+		}
+		if err := s.gitMgr.RollbackTo(session.Checkpoint); err != nil {
+			return nil, NewError(GitConflict, "Failed to rollback", err.Error())
+		}
+		return map[string]interface{}{"success": true}, nil
+	}
+
 	if err := s.gitMgr.Rollback(); err != nil {
 		return nil, NewError(GitConflict, "Failed to rollback", err.Error())
 	}
@@ -621,10 +986,101 @@ func (s *Server) handleGitRollback(ctx context.Context, params json.RawMessage)
 	}, nil
 }
 
+func (s *Server) handleGitBlame(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path string `json:"path"`
+		Rev  string `json:"rev,omitempty"`
+	}
+	json.Unmarshal(params, &input)
+	if input.Path == "" {
+		return nil, NewError(InvalidParams, "path is required", "")
+	}
+
+	lines, err := s.gitMgr.BlameFile(input.Path, input.Rev)
+	if err != nil {
+		return nil, NewError(GitConflict, "Failed to blame file", err.Error())
+	}
+
+	blame := make([]map[string]interface{}, len(lines))
+	for i, l := range lines {
+		blame[i] = map[string]interface{}{
+			"line":    l.Line,
+			"commit":  l.Commit,
+			"author":  l.Author,
+			"date":    l.Date,
+			"content": l.Content,
+		}
+	}
+
+	return map[string]interface{}{"lines": blame}, nil
+}
+
+func (s *Server) handleGitLog(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Path  string `json:"path,omitempty"`
+		Limit int    `json:"limit,omitempty"`
+	}
+	json.Unmarshal(params, &input)
+
+	entries, err := s.gitMgr.Log(input.Path, input.Limit)
+	if err != nil {
+		return nil, NewError(GitConflict, "Failed to get log", err.Error())
+	}
+
+	commits := make([]map[string]interface{}, len(entries))
+	for i, e := range entries {
+		commits[i] = map[string]interface{}{
+			"commit":  e.Commit,
+			"author":  e.Author,
+			"email":   e.Email,
+			"date":    e.Date,
+			"message": e.Message,
+		}
+	}
+
+	return map[string]interface{}{"commits": commits}, nil
+}
+
+func (s *Server) handleGitDiff(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		RevA  string   `json:"rev_a,omitempty"`
+		RevB  string   `json:"rev_b,omitempty"`
+		Paths []string `json:"paths,omitempty"`
+	}
+	json.Unmarshal(params, &input)
+
+	files, err := s.gitMgr.DiffRevisions(input.RevA, input.RevB, input.Paths)
+	if err != nil {
+		return nil, NewError(GitConflict, "Failed to diff revisions", err.Error())
+	}
+
+	return map[string]interface{}{"files": files}, nil
+}
+
+func (s *Server) handleGitStats(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		RevA string `json:"rev_a,omitempty"`
+		RevB string `json:"rev_b,omitempty"`
+	}
+	json.Unmarshal(params, &input)
+
+	stats, err := s.gitMgr.Stats(input.RevA, input.RevB)
+	if err != nil {
+		return nil, NewError(GitConflict, "Failed to compute diff stats", err.Error())
+	}
+
+	return map[string]interface{}{
+		"files_changed": stats.FilesChanged,
+		"insertions":    stats.Insertions,
+		"deletions":     stats.Deletions,
+	}, nil
+}
+
 func (s *Server) handleListFiles(ctx context.Context, params json.RawMessage) (interface{}, error) {
 	var input struct {
 		IncludePatterns []string `json:"include_patterns,omitempty"`
 		ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+		ChangedSince    string   `json:"changed_since,omitempty"`
 	}
 	json.Unmarshal(params, &input)
 
@@ -634,6 +1090,14 @@ func (s *Server) handleListFiles(ctx context.Context, params json.RawMessage) (i
 		return nil, NewError(InternalError, "Failed to list files", err.Error())
 	}
 
+	if input.ChangedSince != "" {
+		changed, err := s.changedSince(input.ChangedSince)
+		if err != nil {
+			return nil, NewError(InvalidParams, "Invalid changed_since", err.Error())
+		}
+		files = filterFiles(files, changed)
+	}
+
 	fileList := make([]map[string]interface{}, len(files))
 	for i, f := range files {
 		fileList[i] = map[string]interface{}{
@@ -651,6 +1115,107 @@ func (s *Server) handleListFiles(ctx context.Context, params json.RawMessage) (i
 	}, nil
 }
 
+// handleListFilesStream is list_files's streaming variant: it walks the
+// project with Walker.WalkStream and sends one JSON-encoded file entry per
+// $/progress notification as it's found, rather than buffering the whole
+// project's file list before replying. The final SuccessResponse carries a
+// StreamResponse plus the same total/root_dir metadata as handleListFiles.
+func (s *Server) handleListFilesStream(ctx context.Context, id interface{}, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		IncludePatterns []string `json:"include_patterns,omitempty"`
+		ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+		ChangedSince    string   `json:"changed_since,omitempty"`
+	}
+	json.Unmarshal(params, &input)
+
+	var changed map[string]bool
+	if input.ChangedSince != "" {
+		var err error
+		changed, err = s.changedSince(input.ChangedSince)
+		if err != nil {
+			return nil, NewError(InvalidParams, "Invalid changed_since", err.Error())
+		}
+	}
+
+	w := walker.New(input.ExcludePatterns, input.IncludePatterns)
+
+	hasher := sha256.New()
+	var total int64
+	seq := 0
+	fileCount := 0
+
+	err := w.WalkStream(s.rootDir, func(f models.FileInfo) error {
+		if changed != nil && !changed[f.Path] {
+			return nil
+		}
+		fileCount++
+
+		entry, err := json.Marshal(map[string]interface{}{
+			"path":     f.Path,
+			"hash":     f.Hash,
+			"language": s.walker.DetectLanguage(f.Path),
+			"size":     len(f.Content),
+		})
+		if err != nil {
+			return err
+		}
+		entry = append(entry, '\n')
+
+		hasher.Write(entry)
+		total += int64(len(entry))
+		s.SendChunk(id, seq, entry, false)
+		seq++
+		return nil
+	})
+	if err != nil {
+		return nil, NewError(InternalError, "Failed to list files", err.Error())
+	}
+
+	s.SendChunk(id, seq, nil, true)
+	seq++
+
+	return map[string]interface{}{
+		"totalBytes": total,
+		"sha256":     hex.EncodeToString(hasher.Sum(nil)),
+		"chunks":     seq,
+		"total":      fileCount,
+		"root_dir":   s.rootDir,
+	}, nil
+}
+
+// changedSince resolves a changed_since value to the set of paths modified
+// after it: a Unix timestamp is served from the watcher's in-memory change
+// log, anything else is treated as a git revision and answered via
+// gitMgr.ChangedSince (`git diff --name-only <rev>..HEAD`).
+func (s *Server) changedSince(since string) (map[string]bool, error) {
+	var paths []string
+	if ts, err := strconv.ParseInt(since, 10, 64); err == nil {
+		paths = s.cache.ChangedSince(time.Unix(ts, 0))
+	} else {
+		gitPaths, err := s.gitMgr.ChangedSince(since)
+		if err != nil {
+			return nil, err
+		}
+		paths = gitPaths
+	}
+
+	set := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		set[p] = true
+	}
+	return set, nil
+}
+
+func filterFiles(files []models.FileInfo, changed map[string]bool) []models.FileInfo {
+	filtered := files[:0]
+	for _, f := range files {
+		if changed[f.Path] {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
 func (s *Server) handleGetComplexity(ctx context.Context, params json.RawMessage) (interface{}, error) {
 	var input struct {
 		Path    string `json:"path"`
@@ -660,19 +1225,24 @@ func (s *Server) handleGetComplexity(ctx context.Context, params json.RawMessage
 		return nil, NewError(InvalidParams, "Invalid params", err.Error())
 	}
 
-	var content string
-	if input.Content != "" {
-		content = input.Content
-	} else {
-		fullPath := filepath.Join(s.rootDir, input.Path)
-		data, err := os.ReadFile(fullPath)
+	content, err := s.resolveContent(input.Path, input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	lang := s.walker.DetectLanguage(input.Path)
+	hash := contentHash(content)
+
+	metrics, ok := s.cache.Complexity(input.Path, hash)
+	if !ok {
+		tree, err := s.ts.Parse(ctx, lang, input.Path, content)
 		if err != nil {
-			return nil, NewError(FileNotFound, "Failed to read file", err.Error())
+			return nil, NewError(ParseFailure, "Failed to parse for complexity", err.Error())
 		}
-		content = string(data)
-	}
 
-	metrics := s.calculateComplexity(content)
+		metrics = treesitter.CalculateComplexity(tree, []byte(content))
+		s.cache.PutComplexity(input.Path, hash, metrics)
+	}
 
 	return map[string]interface{}{
 		"path":    input.Path,
@@ -680,46 +1250,177 @@ func (s *Server) handleGetComplexity(ctx context.Context, params json.RawMessage
 	}, nil
 }
 
-func (s *Server) calculateComplexity(content string) models.ComplexityMetrics {
-	lines := strings.Split(content, "\n")
-	metrics := models.ComplexityMetrics{
-		LinesOfCode: len(lines),
+// handleRunLint runs static-analysis over paths (or the whole project, if
+// omitted), either for the requested language or for every language
+// present in the project (per walker's stats), and groups the resulting
+// diagnostics by file. Severity filters to "at least as severe as", e.g.
+// severity: "error" drops warnings and hints.
+func (s *Server) handleRunLint(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Paths    []string `json:"paths,omitempty"`
+		Language string   `json:"language,omitempty"`
+		Severity string   `json:"severity,omitempty"`
 	}
+	json.Unmarshal(params, &input)
 
-	complexityKeywords := []string{
-		"if ", "else if", "elif ", "else:",
-		"for ", "while ", "case ",
-		"switch ", "try:", "except ",
-		"catch ", "finally:",
+	languages, err := s.lintLanguages(input.Language)
+	if err != nil {
+		return nil, NewError(InvalidParams, "Invalid language", err.Error())
 	}
 
-	nesting := 0
-	for _, line := range lines {
-		stripped := strings.TrimSpace(line)
-
-		for _, kw := range complexityKeywords {
-			if strings.HasPrefix(stripped, kw) {
-				metrics.CyclomaticComplexity++
-			}
+	var all []models.Diagnostic
+	for _, lang := range languages {
+		diags, err := s.linter.Lint(ctx, lang, input.Paths)
+		if err != nil {
+			continue
 		}
+		all = append(all, diags...)
+	}
 
-		if strings.HasPrefix(stripped, "if ") || strings.HasPrefix(stripped, "elif ") ||
-			strings.HasPrefix(stripped, "for ") || strings.HasPrefix(stripped, "while ") {
-			metrics.CognitiveComplexity += 1 + nesting
-			nesting++
-		}
+	if input.Severity != "" {
+		all = linter.FilterSeverity(all, input.Severity)
+	}
 
-		if strings.Contains(stripped, " and ") || strings.Contains(stripped, " or ") ||
-			strings.Contains(stripped, "&&") || strings.Contains(stripped, "||") {
-			metrics.CyclomaticComplexity++
+	return map[string]interface{}{
+		"diagnostics": linter.GroupByFile(all),
+		"total":       len(all),
+	}, nil
+}
+
+// lintLanguages resolves an explicit language param, or falls back to
+// every language walker finds actually present in the project.
+func (s *Server) lintLanguages(language string) ([]models.Language, error) {
+	if language != "" {
+		lang := models.Language(language)
+		if lang == models.LanguageUnknown {
+			return nil, fmt.Errorf("unknown language: %s", language)
 		}
+		return []models.Language{lang}, nil
+	}
+
+	stats, err := s.walker.GetProjectStats(s.rootDir)
+	if err != nil {
+		return nil, err
 	}
 
-	return metrics
+	var languages []models.Language
+	for lang, count := range stats.ByLanguage {
+		if count > 0 && lang != models.LanguageUnknown {
+			languages = append(languages, lang)
+		}
+	}
+	return languages, nil
 }
 
 func (s *Server) Shutdown() {
 	if s.lspMgr != nil {
 		s.lspMgr.Shutdown()
 	}
+
+	s.watchMu.Lock()
+	for root, cancel := range s.watches {
+		cancel()
+		delete(s.watches, root)
+	}
+	s.watchMu.Unlock()
+}
+
+// handleProjectWatch starts a debounced Walker.Watch on the given root
+// (defaulting to rootDir) and forwards each changed path in every
+// ChangeSet to the client as a project/fileChanged notification until
+// project/unwatch is called or the server shuts down.
+func (s *Server) handleProjectWatch(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Root string `json:"root"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &input); err != nil {
+			return nil, NewError(InvalidParams, "Invalid params", err.Error())
+		}
+	}
+
+	root := input.Root
+	if root == "" {
+		root = s.rootDir
+	}
+
+	s.watchMu.Lock()
+	if _, watching := s.watches[root]; watching {
+		s.watchMu.Unlock()
+		return map[string]string{"status": "already_watching", "root": root}, nil
+	}
+	s.watchMu.Unlock()
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	changes, err := s.walker.Watch(watchCtx, root, walker.WithRenameDetection(true))
+	if err != nil {
+		cancel()
+		return nil, NewError(InternalError, "Failed to start watcher", err.Error())
+	}
+
+	s.watchMu.Lock()
+	s.watches[root] = cancel
+	s.watchMu.Unlock()
+
+	go func() {
+		for cs := range changes {
+			if s.notify == nil {
+				continue
+			}
+			for _, f := range cs.Added {
+				s.notify(NewNotification("project/fileChanged", map[string]string{
+					"root": root, "path": f.Path, "hash": f.Hash, "changeType": "created",
+				}))
+			}
+			for _, f := range cs.Modified {
+				s.notify(NewNotification("project/fileChanged", map[string]string{
+					"root": root, "path": f.Path, "hash": f.Hash, "changeType": "modified",
+				}))
+			}
+			for _, f := range cs.Removed {
+				s.notify(NewNotification("project/fileChanged", map[string]string{
+					"root": root, "path": f.Path, "changeType": "deleted",
+				}))
+			}
+			for _, r := range cs.Renamed {
+				s.notify(NewNotification("project/fileChanged", map[string]string{
+					"root": root, "path": r.Path, "oldPath": r.OldPath, "hash": r.Hash, "changeType": "renamed",
+				}))
+			}
+		}
+	}()
+
+	return map[string]string{"status": "watching", "root": root}, nil
+}
+
+// handleProjectUnwatch stops the Watcher previously started for root by
+// project/watch (defaulting to rootDir).
+func (s *Server) handleProjectUnwatch(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		Root string `json:"root"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &input); err != nil {
+			return nil, NewError(InvalidParams, "Invalid params", err.Error())
+		}
+	}
+
+	root := input.Root
+	if root == "" {
+		root = s.rootDir
+	}
+
+	s.watchMu.Lock()
+	cancel, ok := s.watches[root]
+	if ok {
+		delete(s.watches, root)
+	}
+	s.watchMu.Unlock()
+
+	if !ok {
+		return nil, NewError(InvalidParams, "Not watching root", root)
+	}
+	cancel()
+
+	return map[string]string{"status": "unwatched", "root": root}, nil
 }
@@ -0,0 +1,221 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// newSessionID returns a random 16-byte hex identifier for a Session that
+// wasn't given one explicitly.
+func newSessionID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand failing is effectively unrecoverable, but a
+		// collision-prone fallback is still better than panicking.
+		return fmt.Sprintf("session-%d", os.Getpid())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// getOrCreateSession returns the Session for id, creating and registering
+// one (with a freshly generated ID) if id is empty or unknown.
+func (s *Server) getOrCreateSession(id string) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id != "" {
+		if session, ok := s.sessions[id]; ok {
+			return session
+		}
+	} else {
+		id = newSessionID()
+	}
+
+	session := &Session{ID: id, Symbols: make(map[string][]models.Symbol)}
+	s.sessions[id] = session
+	return session
+}
+
+type patchInput struct {
+	Path         string `json:"path"`
+	Diff         string `json:"diff"`
+	ExpectedHash string `json:"expected_hash,omitempty"`
+}
+
+// handleApplyPatch applies one or more diffs as a single all-or-nothing
+// change: it checkpoints the working tree, rejects the whole batch if any
+// file has drifted from the caller's expected_hash, stages every new
+// version in a temp directory, then fsync-renames them into place.
+// Any failure once the checkpoint's commit hash is known rolls the worktree
+// back to it.
+func (s *Server) handleApplyPatch(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		SessionID string       `json:"session_id,omitempty"`
+		Patches   []patchInput `json:"patches"`
+		Message   string       `json:"message,omitempty"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, NewError(InvalidParams, "Invalid params", err.Error())
+	}
+	if len(input.Patches) == 0 {
+		return nil, NewError(InvalidParams, "patches must not be empty", nil)
+	}
+
+	session := s.getOrCreateSession(input.SessionID)
+
+	message := input.Message
+	if message == "" {
+		message = "checkpoint before apply_patch"
+	}
+	if err := s.gitMgr.CreateCheckpoint(message); err != nil {
+		return nil, NewError(GitConflict, "Failed to create checkpoint", err.Error())
+	}
+	checkpoint, err := s.gitMgr.CurrentCommit()
+	if err != nil {
+		return nil, NewError(InternalError, "Failed to get checkpoint commit hash", err.Error())
+	}
+
+	s.mu.Lock()
+	session.Checkpoint = checkpoint
+	s.mu.Unlock()
+
+	originals := make(map[string]string, len(input.Patches))
+	var drifted []string
+	for _, patch := range input.Patches {
+		fullPath := filepath.Join(s.rootDir, patch.Path)
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			s.gitMgr.RollbackTo(session.Checkpoint)
+			return nil, NewError(FileNotFound, "Failed to read file", err.Error())
+		}
+		originals[patch.Path] = string(content)
+
+		if patch.ExpectedHash == "" {
+			continue
+		}
+		hash := sha256.Sum256(content)
+		if hex.EncodeToString(hash[:]) != patch.ExpectedHash {
+			drifted = append(drifted, patch.Path)
+		}
+	}
+
+	if len(drifted) > 0 {
+		s.gitMgr.RollbackTo(session.Checkpoint)
+		return nil, NewError(HashConflict, "Files have drifted from expected_hash", drifted)
+	}
+
+	stagingDir, err := os.MkdirTemp(s.rootDir, ".reducto-patch-")
+	if err != nil {
+		s.gitMgr.RollbackTo(session.Checkpoint)
+		return nil, NewError(InternalError, "Failed to create staging directory", err.Error())
+	}
+	defer os.RemoveAll(stagingDir)
+
+	staged := make(map[string]string, len(input.Patches))
+	newContents := make(map[string]string, len(input.Patches))
+	for _, patch := range input.Patches {
+		newContent, rejects, err := ApplyUnifiedDiff(originals[patch.Path], patch.Diff)
+		if err != nil {
+			s.gitMgr.RollbackTo(session.Checkpoint)
+			return nil, NewError(ParseFailure, "Failed to apply diff", fmt.Sprintf("%s: %s", patch.Path, err))
+		}
+		if len(rejects) > 0 {
+			s.gitMgr.RollbackTo(session.Checkpoint)
+			return nil, NewError(ParseFailure, "Diff did not match file content", fmt.Sprintf("%s: %s", patch.Path, rejectSummary(rejects)))
+		}
+		newContents[patch.Path] = newContent
+
+		stagePath := filepath.Join(stagingDir, patch.Path)
+		if err := os.MkdirAll(filepath.Dir(stagePath), 0755); err != nil {
+			s.gitMgr.RollbackTo(session.Checkpoint)
+			return nil, NewError(InternalError, "Failed to stage patch", err.Error())
+		}
+
+		f, err := os.Create(stagePath)
+		if err != nil {
+			s.gitMgr.RollbackTo(session.Checkpoint)
+			return nil, NewError(InternalError, "Failed to stage patch", err.Error())
+		}
+		if _, err := f.WriteString(newContent); err != nil {
+			f.Close()
+			s.gitMgr.RollbackTo(session.Checkpoint)
+			return nil, NewError(InternalError, "Failed to stage patch", err.Error())
+		}
+		if err := f.Sync(); err != nil {
+			f.Close()
+			s.gitMgr.RollbackTo(session.Checkpoint)
+			return nil, NewError(InternalError, "Failed to fsync staged patch", err.Error())
+		}
+		f.Close()
+
+		staged[patch.Path] = stagePath
+	}
+
+	for _, patch := range input.Patches {
+		fullPath := filepath.Join(s.rootDir, patch.Path)
+		if err := os.Rename(staged[patch.Path], fullPath); err != nil {
+			s.gitMgr.RollbackTo(session.Checkpoint)
+			return nil, NewError(InternalError, "Failed to move staged patch into place", err.Error())
+		}
+	}
+
+	s.mu.Lock()
+	for _, patch := range input.Patches {
+		content := newContents[patch.Path]
+		hash := sha256.Sum256([]byte(content))
+		hashHex := hex.EncodeToString(hash[:])
+
+		info := models.FileInfo{Path: patch.Path, Content: content, Hash: hashHex}
+		replaced := false
+		for i, existing := range session.Files {
+			if existing.Path == patch.Path {
+				session.Files[i] = info
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			session.Files = append(session.Files, info)
+		}
+
+		lang := s.walker.DetectLanguage(patch.Path)
+		if symbols, err := s.symbolsForFile(ctx, patch.Path, content, lang); err == nil {
+			session.Symbols[hashHex] = symbols
+		}
+	}
+	s.mu.Unlock()
+
+	return map[string]interface{}{
+		"success":    true,
+		"session_id": session.ID,
+		"checkpoint": session.Checkpoint,
+		"applied":    len(input.Patches),
+	}, nil
+}
+
+// handleSessionEnd discards a Session's in-memory state. It does not touch
+// the checkpoint commit already recorded in git history.
+func (s *Server) handleSessionEnd(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var input struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := json.Unmarshal(params, &input); err != nil {
+		return nil, NewError(InvalidParams, "Invalid params", err.Error())
+	}
+
+	s.mu.Lock()
+	delete(s.sessions, input.SessionID)
+	s.mu.Unlock()
+
+	return map[string]interface{}{
+		"success": true,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWantsStream(t *testing.T) {
+	if wantsStream(json.RawMessage(`{"path":"a.go"}`)) {
+		t.Error("expected wantsStream = false without _meta.stream")
+	}
+	if !wantsStream(json.RawMessage(`{"path":"a.go","_meta":{"stream":true}}`)) {
+		t.Error("expected wantsStream = true with _meta.stream = true")
+	}
+}
+
+func TestHandleReadFileStreamSendsChunksAndMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := "package main\n\nfunc main() {}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	s := NewServer(tmpDir)
+
+	var notifications []*Notification
+	s.notify = func(n *Notification) { notifications = append(notifications, n) }
+
+	params, _ := json.Marshal(map[string]interface{}{
+		"path":  "main.go",
+		"_meta": map[string]bool{"stream": true},
+	})
+
+	result, err := s.handleReadFileStream(context.Background(), float64(1), params)
+	if err != nil {
+		t.Fatalf("handleReadFileStream() error = %v", err)
+	}
+
+	if len(notifications) != 1 {
+		t.Fatalf("expected 1 $/progress notification for a small file, got %d", len(notifications))
+	}
+	progress := notifications[0]
+	if progress.Method != "$/progress" {
+		t.Errorf("expected $/progress, got %q", progress.Method)
+	}
+	payload, ok := progress.Params.(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected map params, got %T", progress.Params)
+	}
+	if done, _ := payload["done"].(bool); !done {
+		t.Error("expected the only chunk to be marked done")
+	}
+	chunkB64, _ := payload["chunk"].(string)
+	decoded, err := base64.StdEncoding.DecodeString(chunkB64)
+	if err != nil {
+		t.Fatalf("failed to decode chunk: %v", err)
+	}
+	if string(decoded) != content {
+		t.Errorf("decoded chunk = %q, want %q", decoded, content)
+	}
+
+	stream, ok := result.(StreamResponse)
+	if !ok {
+		t.Fatalf("expected a StreamResponse, got %T", result)
+	}
+	if stream.TotalBytes != int64(len(content)) {
+		t.Errorf("expected TotalBytes = %d, got %d", len(content), stream.TotalBytes)
+	}
+	if stream.Chunks != 1 {
+		t.Errorf("expected 1 chunk, got %d", stream.Chunks)
+	}
+}
+
+func TestHandleReadFileStreamRefusesIgnoredPath(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("*.secret\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "creds.secret"), []byte("sk-test"), 0644)
+
+	s := NewServer(tmpDir)
+	s.notify = func(n *Notification) {}
+
+	params, _ := json.Marshal(map[string]string{"path": "creds.secret"})
+	if _, err := s.handleReadFileStream(context.Background(), float64(1), params); err == nil {
+		t.Fatal("expected an error for a gitignored path, got nil")
+	} else if errObj, ok := err.(*ErrorObject); !ok || errObj.Code != FileIgnored {
+		t.Errorf("expected FileIgnored error, got %v", err)
+	}
+}
+
+func TestHandleRequestFallsBackWhenStreamUnsupported(t *testing.T) {
+	s := NewServer(t.TempDir())
+	s.notify = func(n *Notification) {}
+
+	params, _ := json.Marshal(map[string]interface{}{"_meta": map[string]bool{"stream": true}})
+	req := &Request{JSONRPC: JSONRPCVersion, ID: float64(1), Method: "initialize", Params: params}
+
+	resp := s.handleRequest(context.Background(), req)
+	if resp == nil || resp.Error != nil {
+		t.Errorf("expected initialize (no streaming variant) to succeed normally, got %+v", resp)
+	}
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// update regenerates every fixture's expected.yaml from the parser's
+// current output: go test ./internal/parser/... -run TestParserFixtures -update
+var update = flag.Bool("update", false, "update golden fixture files")
+
+var fixtureLanguages = map[string]models.Language{
+	"go":         models.LanguageGo,
+	"python":     models.LanguagePython,
+	"javascript": models.LanguageJavaScript,
+}
+
+// fixtureExpectation mirrors ParseResult in a form that round-trips cleanly
+// through YAML, so goldens stay human-readable and diffable.
+type fixtureExpectation struct {
+	Symbols []models.Symbol `yaml:"symbols"`
+	Imports []string        `yaml:"imports"`
+	Exports []string        `yaml:"exports"`
+}
+
+// TestParserFixtures walks testdata/<language>/<case>/, parsing each
+// input.* file and comparing the result against its sibling expected.yaml.
+// Add a new case by dropping a fixture directory in place; there is no
+// registration step.
+func TestParserFixtures(t *testing.T) {
+	for dirName, language := range fixtureLanguages {
+		langDir := filepath.Join("testdata", dirName)
+		entries, err := os.ReadDir(langDir)
+		if err != nil {
+			t.Fatalf("ReadDir(%s) error = %v", langDir, err)
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			caseDir := filepath.Join(langDir, entry.Name())
+
+			t.Run(dirName+"/"+entry.Name(), func(t *testing.T) {
+				runParserFixture(t, caseDir, language)
+			})
+		}
+	}
+}
+
+func runParserFixture(t *testing.T, caseDir string, language models.Language) {
+	matches, err := filepath.Glob(filepath.Join(caseDir, "input.*"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected exactly one input.* file in %s, found %v (err=%v)", caseDir, matches, err)
+	}
+	inputPath := matches[0]
+
+	input, err := os.ReadFile(inputPath)
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error = %v", inputPath, err)
+	}
+
+	result, err := New().Parse(string(input), language)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	expectedPath := filepath.Join(caseDir, "expected.yaml")
+
+	if *update {
+		writeFixtureGolden(t, expectedPath, result)
+		return
+	}
+
+	expectedRaw, err := os.ReadFile(expectedPath)
+	if err != nil {
+		t.Fatalf("ReadFile(%s) error = %v (re-run with -update to generate it)", expectedPath, err)
+	}
+
+	var want fixtureExpectation
+	if err := yaml.Unmarshal(expectedRaw, &want); err != nil {
+		t.Fatalf("failed to parse %s: %v", expectedPath, err)
+	}
+
+	got := fixtureExpectation{Symbols: result.Symbols, Imports: result.Imports, Exports: result.Exports}
+	assertFixtureEqual(t, want, got)
+}
+
+func assertFixtureEqual(t *testing.T, want, got fixtureExpectation) {
+	t.Helper()
+
+	if len(want.Symbols) != len(got.Symbols) {
+		t.Fatalf("symbols count = %d, want %d\ngot:  %+v\nwant: %+v", len(got.Symbols), len(want.Symbols), got.Symbols, want.Symbols)
+	}
+	for i := range want.Symbols {
+		if got.Symbols[i] != want.Symbols[i] {
+			t.Errorf("symbol[%d] = %+v, want %+v", i, got.Symbols[i], want.Symbols[i])
+		}
+	}
+
+	if len(want.Imports) != len(got.Imports) {
+		t.Errorf("imports = %v, want %v", got.Imports, want.Imports)
+	}
+	if len(want.Exports) != len(got.Exports) {
+		t.Errorf("exports = %v, want %v", got.Exports, want.Exports)
+	}
+}
+
+func writeFixtureGolden(t *testing.T, path string, result *ParseResult) {
+	t.Helper()
+
+	out := fixtureExpectation{Symbols: result.Symbols, Imports: result.Imports, Exports: result.Exports}
+	raw, err := yaml.Marshal(out)
+	if err != nil {
+		t.Fatalf("failed to marshal golden for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatalf("failed to write golden %s: %v", path, err)
+	}
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"context"
+	"strings"
+
+	"github.com/alexkarsten/reducto/internal/git"
+	"github.com/alexkarsten/reducto/internal/lsp"
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// LSPBackend extracts CodeBlocks via a running language server's
+// textDocument/documentSymbol, refining each symbol's end line with
+// textDocument/foldingRange where the server reports one. When no client
+// is registered for a file's language, or the server call fails, it
+// defers to fallback (normally a *Parser) so the tool still works in CI
+// environments without a language server installed.
+type LSPBackend struct {
+	manager  *lsp.Manager
+	fallback Backend
+}
+
+// NewLSPBackend returns an LSPBackend that dispatches through manager and
+// falls back to fallback whenever LSP isn't usable for a file.
+func NewLSPBackend(manager *lsp.Manager, fallback Backend) *LSPBackend {
+	return &LSPBackend{manager: manager, fallback: fallback}
+}
+
+func (b *LSPBackend) FindBlocks(file, content string, language models.Language, blamer Blamer) []models.CodeBlock {
+	client := b.manager.GetClient(string(language))
+	if client == nil || !client.IsInitialized() {
+		return b.fallback.FindBlocks(file, content, language, blamer)
+	}
+
+	ctx := context.Background()
+	uri := "file://" + file
+
+	symbols, err := client.DocumentSymbol(ctx, uri)
+	if err != nil || len(symbols) == 0 {
+		return b.fallback.FindBlocks(file, content, language, blamer)
+	}
+
+	foldRanges, _ := client.FoldingRange(ctx, uri)
+
+	lines := strings.Split(content, "\n")
+
+	var blameLines []git.BlameLine
+	if blamer != nil {
+		if bl, err := blamer.Blame(file); err == nil {
+			blameLines = bl
+			// When the file ends in a newline, strings.Split leaves a
+			// trailing empty element that git.Blame has no line for;
+			// pad with a synthetic empty line so indices still line up
+			// with lines.
+			if len(blameLines) == len(lines)-1 {
+				blameLines = append(blameLines, git.BlameLine{})
+			}
+		}
+	}
+
+	p := New()
+	blocks := make([]models.CodeBlock, 0, len(symbols))
+	for _, sym := range symbols {
+		start, end := sym.StartLine, sym.EndLine
+		if folded, ok := foldedEndLine(foldRanges, start); ok && folded > end {
+			end = folded
+		}
+
+		s := start - 1
+		if s < 0 {
+			s = 0
+		}
+		e := end
+		if e > len(lines) {
+			e = len(lines)
+		}
+
+		blockContent := strings.Join(lines[s:e], "\n")
+
+		block := models.CodeBlock{
+			File:       file,
+			StartLine:  start,
+			EndLine:    end,
+			Content:    blockContent,
+			Language:   language,
+			SymbolType: sym.Type,
+			SymbolName: sym.Name,
+			Metrics:    p.CalculateComplexity(blockContent),
+		}
+
+		if latest, ok := latestBlame(blameLines, s, e); ok {
+			block.LastAuthor = latest.Author
+			block.LastCommit = latest.Commit
+			block.LastModified = latest.Date
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// foldedEndLine returns the 1-indexed end line of the folding range whose
+// 1-indexed start matches startLine, if any, so a symbol's closing line
+// can reflect the server's own folding boundary rather than
+// documentSymbol's (sometimes narrower) Range.
+func foldedEndLine(ranges []lsp.FoldingRange, startLine int) (int, bool) {
+	for _, r := range ranges {
+		if r.StartLine+1 == startLine {
+			return r.EndLine + 1, true
+		}
+	}
+	return 0, false
+}
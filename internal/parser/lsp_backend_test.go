@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexkarsten/reducto/internal/lsp"
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// fakeLSPClient implements lsp.Client, returning a fixed DocumentSymbol
+// result so LSPBackend can be tested without a real language server.
+type fakeLSPClient struct {
+	symbols  []models.Symbol
+	folds    []lsp.FoldingRange
+	symErr   error
+	initDone bool
+}
+
+func (f *fakeLSPClient) Initialize(ctx context.Context, rootURI string) error { return nil }
+func (f *fakeLSPClient) FindReferences(ctx context.Context, uri string, line, column int) ([]lsp.Reference, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) GoToDefinition(ctx context.Context, uri string, line, column int) (*lsp.Definition, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) DidOpen(uri, content string) error { return nil }
+func (f *fakeLSPClient) DidClose(uri string) error         { return nil }
+func (f *fakeLSPClient) Diagnostics(ctx context.Context, uri string) ([]lsp.Diagnostic, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) CodeActions(ctx context.Context, uri string, rng lsp.Range, only []string) ([]lsp.CodeAction, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) PrepareCallHierarchy(ctx context.Context, uri string, line, column int) ([]lsp.CallHierarchyItem, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) IncomingCalls(ctx context.Context, item lsp.CallHierarchyItem) ([]lsp.CallHierarchyIncomingCall, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) OutgoingCalls(ctx context.Context, item lsp.CallHierarchyItem) ([]lsp.CallHierarchyOutgoingCall, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) Rename(ctx context.Context, uri string, line, column int, newName string) (*lsp.WorkspaceEdit, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) WorkspaceSymbols(ctx context.Context, query string) ([]lsp.SymbolInformation, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) DocumentSymbol(ctx context.Context, uri string) ([]models.Symbol, error) {
+	return f.symbols, f.symErr
+}
+func (f *fakeLSPClient) DocumentSymbols(ctx context.Context, uri string) ([]lsp.DocumentSymbol, error) {
+	return nil, nil
+}
+func (f *fakeLSPClient) FoldingRange(ctx context.Context, uri string) ([]lsp.FoldingRange, error) {
+	return f.folds, nil
+}
+func (f *fakeLSPClient) Hover(ctx context.Context, uri string, line, column int) (string, error) {
+	return "", nil
+}
+func (f *fakeLSPClient) Shutdown() error     { return nil }
+func (f *fakeLSPClient) IsInitialized() bool { return f.initDone }
+
+func TestLSPBackend_FallsBackWhenNoClientRegistered(t *testing.T) {
+	manager := lsp.NewManager()
+	backend := NewLSPBackend(manager, New())
+
+	content := "def func1():\n    pass\n"
+	blocks := backend.FindBlocks("a.py", content, models.LanguagePython, nil)
+
+	if len(blocks) < 1 {
+		t.Fatalf("Expected the regex fallback to find at least 1 block, got %d", len(blocks))
+	}
+}
+
+func TestLSPBackend_UsesDocumentSymbolWhenAvailable(t *testing.T) {
+	manager := lsp.NewManager()
+	manager.Register(string(models.LanguagePython), &fakeLSPClient{
+		initDone: true,
+		symbols: []models.Symbol{
+			{Name: "func1", Type: "function", StartLine: 1, EndLine: 2},
+		},
+		folds: []lsp.FoldingRange{
+			{StartLine: 0, EndLine: 2},
+		},
+	})
+	backend := NewLSPBackend(manager, New())
+
+	content := "def func1():\n    pass\n    # trailing\n"
+	blocks := backend.FindBlocks("a.py", content, models.LanguagePython, nil)
+
+	if len(blocks) != 1 {
+		t.Fatalf("Expected exactly 1 block from the fake LSP client, got %d", len(blocks))
+	}
+	if blocks[0].EndLine != 3 {
+		t.Errorf("Expected EndLine extended to 3 by the folding range, got %d", blocks[0].EndLine)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/alexkarsten/reducto/internal/git"
 	"github.com/alexkarsten/reducto/pkg/models"
 )
 
@@ -277,12 +278,47 @@ func (p *Parser) CalculateComplexity(content string) models.ComplexityMetrics {
 	return metrics
 }
 
-func (p *Parser) FindBlocks(content string, language models.Language) []models.CodeBlock {
+// Blamer supplies per-line blame info for a file, typically
+// *git.Manager.Blame. FindBlocks uses it, when non-nil, to enrich each
+// CodeBlock with recency data so dehydration can prioritize recently
+// churned code over static complexity alone.
+type Blamer interface {
+	Blame(file string) ([]git.BlameLine, error)
+}
+
+// Backend extracts CodeBlocks from a file's content. Parser's own
+// line-anchored regexes are the default, always-available backend;
+// LSPBackend prefers a running language server's understanding of the
+// code when one is available, falling back to a regex Backend otherwise.
+type Backend interface {
+	FindBlocks(file, content string, language models.Language, blamer Blamer) []models.CodeBlock
+}
+
+var _ Backend = (*Parser)(nil)
+
+// FindBlocks splits content into one CodeBlock per top-level symbol. file
+// is recorded on each block and, when blamer is non-nil, used to look up
+// blame info; pass "" and nil respectively if neither is available.
+func (p *Parser) FindBlocks(file, content string, language models.Language, blamer Blamer) []models.CodeBlock {
 	result, _ := p.Parse(content, language)
 	blocks := []models.CodeBlock{}
 
 	lines := strings.Split(content, "\n")
 
+	var blameLines []git.BlameLine
+	if blamer != nil {
+		if bl, err := blamer.Blame(file); err == nil {
+			blameLines = bl
+			// When the file ends in a newline, strings.Split leaves a
+			// trailing empty element that git.Blame has no line for;
+			// pad with a synthetic empty line so indices still line up
+			// with lines.
+			if len(blameLines) == len(lines)-1 {
+				blameLines = append(blameLines, git.BlameLine{})
+			}
+		}
+	}
+
 	for _, sym := range result.Symbols {
 		start := sym.StartLine - 1
 		if start < 0 {
@@ -295,8 +331,8 @@ func (p *Parser) FindBlocks(content string, language models.Language) []models.C
 
 		blockContent := strings.Join(lines[start:end], "\n")
 
-		blocks = append(blocks, models.CodeBlock{
-			File:       "",
+		block := models.CodeBlock{
+			File:       file,
 			StartLine:  sym.StartLine,
 			EndLine:    sym.EndLine,
 			Content:    blockContent,
@@ -304,8 +340,38 @@ func (p *Parser) FindBlocks(content string, language models.Language) []models.C
 			SymbolType: sym.Type,
 			SymbolName: sym.Name,
 			Metrics:    p.CalculateComplexity(blockContent),
-		})
+		}
+
+		if latest, ok := latestBlame(blameLines, start, end); ok {
+			block.LastAuthor = latest.Author
+			block.LastCommit = latest.Commit
+			block.LastModified = latest.Date
+		}
+
+		blocks = append(blocks, block)
 	}
 
 	return blocks
 }
+
+// latestBlame returns the most recent blame entry among blameLines[start:end]
+// (clamped to blameLines' bounds), so a block's recency reflects whichever
+// of its lines was touched last.
+func latestBlame(blameLines []git.BlameLine, start, end int) (git.BlameLine, bool) {
+	if end > len(blameLines) {
+		end = len(blameLines)
+	}
+	if start >= end {
+		return git.BlameLine{}, false
+	}
+
+	var latest git.BlameLine
+	var found bool
+	for _, bl := range blameLines[start:end] {
+		if !found || bl.Date.After(latest.Date) {
+			latest = bl
+			found = true
+		}
+	}
+	return latest, found
+}
@@ -2,77 +2,15 @@ package parser
 
 import (
 	"testing"
+	"time"
 
+	"github.com/alexkarsten/reducto/internal/git"
 	"github.com/alexkarsten/reducto/pkg/models"
 )
 
-func TestParse_Python(t *testing.T) {
-	p := New()
-
-	content := `
-def hello():
-    pass
-
-class World:
-    def greet(self):
-        pass
-`
-
-	result, err := p.Parse(content, models.LanguagePython)
-	if err != nil {
-		t.Fatalf("Parse failed: %v", err)
-	}
-
-	if len(result.Symbols) < 2 {
-		t.Errorf("Expected at least 2 symbols, got %d", len(result.Symbols))
-	}
-}
-
-func TestParse_Go(t *testing.T) {
-	p := New()
-
-	content := `
-package main
-
-func hello() {}
-
-type World struct {
-	name string
-}
-
-func (w *World) greet() {}
-`
-
-	result, err := p.Parse(content, models.LanguageGo)
-	if err != nil {
-		t.Fatalf("Parse failed: %v", err)
-	}
-
-	if len(result.Symbols) < 2 {
-		t.Errorf("Expected at least 2 symbols, got %d", len(result.Symbols))
-	}
-}
-
-func TestParse_JavaScript(t *testing.T) {
-	p := New()
-
-	content := `
-function hello() {}
-
-class World {
-    greet() {}
-}
-`
-
-	result, err := p.Parse(content, models.LanguageJavaScript)
-	if err != nil {
-		t.Fatalf("Parse failed: %v", err)
-	}
-
-	if len(result.Symbols) < 2 {
-		t.Errorf("Expected at least 2 symbols, got %d", len(result.Symbols))
-	}
-}
+// TestParse_Python, TestParse_Go, and TestParse_JavaScript were replaced by
+// the fixture-driven TestParserFixtures in fixtures_test.go, which checks
+// full symbol shape (not just count) against testdata/<language>/ goldens.
 
 func TestParse_Imports(t *testing.T) {
 	p := New()
@@ -143,13 +81,48 @@ def func2():
     pass
 `
 
-	blocks := p.FindBlocks(content, models.LanguagePython)
+	blocks := p.FindBlocks("", content, models.LanguagePython, nil)
 
 	if len(blocks) < 2 {
 		t.Errorf("Expected at least 2 blocks, got %d", len(blocks))
 	}
 }
 
+type fakeBlamer struct {
+	lines []git.BlameLine
+}
+
+func (f fakeBlamer) Blame(file string) ([]git.BlameLine, error) {
+	return f.lines, nil
+}
+
+func TestFindBlocks_EnrichesWithBlame(t *testing.T) {
+	p := New()
+
+	content := `def func1():
+    pass
+`
+
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	blamer := fakeBlamer{lines: []git.BlameLine{
+		{Commit: "aaaaaaaa", Author: "Alice", Date: older},
+		{Commit: "bbbbbbbb", Author: "Bob", Date: newer},
+	}}
+
+	blocks := p.FindBlocks("func1.py", content, models.LanguagePython, blamer)
+
+	if len(blocks) < 1 {
+		t.Fatalf("Expected at least 1 block, got %d", len(blocks))
+	}
+	if blocks[0].LastAuthor != "Bob" || blocks[0].LastCommit != "bbbbbbbb" {
+		t.Errorf("Expected the most recent blame line (Bob), got %+v", blocks[0])
+	}
+	if !blocks[0].LastModified.Equal(newer) {
+		t.Errorf("Expected LastModified %v, got %v", newer, blocks[0].LastModified)
+	}
+}
+
 func TestTSParser_Fallback(t *testing.T) {
 	p := NewTSParser()
 
@@ -0,0 +1,10 @@
+
+package main
+
+func hello() {}
+
+type World struct {
+	name string
+}
+
+func (w *World) greet() {}
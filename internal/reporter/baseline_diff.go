@@ -0,0 +1,358 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SymbolStatus classifies how a hotspot's complexity changed between two
+// baselines.
+type SymbolStatus string
+
+const (
+	SymbolNew       SymbolStatus = "new"
+	SymbolRemoved   SymbolStatus = "removed"
+	SymbolWorsened  SymbolStatus = "worsened"
+	SymbolImproved  SymbolStatus = "improved"
+	SymbolUnchanged SymbolStatus = "unchanged"
+)
+
+// SymbolDiff is one symbol's complexity comparison between two baselines.
+type SymbolDiff struct {
+	File             string       `json:"file"`
+	Symbol           string       `json:"symbol"`
+	Status           SymbolStatus `json:"status"`
+	CyclomaticBefore int          `json:"cyclomatic_before"`
+	CyclomaticAfter  int          `json:"cyclomatic_after"`
+	CognitiveBefore  int          `json:"cognitive_before"`
+	CognitiveAfter   int          `json:"cognitive_after"`
+}
+
+// BaselineDiff summarizes how a baseline scan changed between two sessions.
+type BaselineDiff struct {
+	PrevSessionID       string       `json:"prev_session_id"`
+	CurrSessionID       string       `json:"curr_session_id"`
+	Symbols             []SymbolDiff `json:"symbols"`
+	NewCount            int          `json:"new_count"`
+	RemovedCount        int          `json:"removed_count"`
+	WorsenedCount       int          `json:"worsened_count"`
+	ImprovedCount       int          `json:"improved_count"`
+	UnchangedCount      int          `json:"unchanged_count"`
+	TotalFilesBefore    int          `json:"total_files_before"`
+	TotalFilesAfter     int          `json:"total_files_after"`
+	TotalSymbolsBefore  int          `json:"total_symbols_before"`
+	TotalSymbolsAfter   int          `json:"total_symbols_after"`
+	AvgCyclomaticBefore float64      `json:"avg_cyclomatic_before"`
+	AvgCyclomaticAfter  float64      `json:"avg_cyclomatic_after"`
+	AvgCognitiveBefore  float64      `json:"avg_cognitive_before"`
+	AvgCognitiveAfter   float64      `json:"avg_cognitive_after"`
+}
+
+type hotspotKey struct {
+	file   string
+	symbol string
+}
+
+// CompareBaselines classifies every hotspot seen in prev and/or curr into
+// New, Removed, Worsened, Improved, or Unchanged, using r's configured
+// complexity thresholds as the delta a symbol must cross to count as a
+// regression or improvement, and aggregates file/symbol/average-complexity
+// totals for both sides.
+func (r *Reporter) CompareBaselines(prev, curr *BaselineResult) (*BaselineDiff, error) {
+	if prev == nil || curr == nil {
+		return nil, fmt.Errorf("CompareBaselines requires non-nil prev and curr baselines")
+	}
+
+	prevByKey := make(map[hotspotKey]ComplexityHotspot, len(prev.Hotspots))
+	for _, h := range prev.Hotspots {
+		prevByKey[hotspotKey{h.File, h.Symbol}] = h
+	}
+	currByKey := make(map[hotspotKey]ComplexityHotspot, len(curr.Hotspots))
+	for _, h := range curr.Hotspots {
+		currByKey[hotspotKey{h.File, h.Symbol}] = h
+	}
+
+	keys := make([]hotspotKey, 0, len(prevByKey)+len(currByKey))
+	seen := make(map[hotspotKey]bool)
+	for _, h := range prev.Hotspots {
+		k := hotspotKey{h.File, h.Symbol}
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	for _, h := range curr.Hotspots {
+		k := hotspotKey{h.File, h.Symbol}
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].file != keys[j].file {
+			return keys[i].file < keys[j].file
+		}
+		return keys[i].symbol < keys[j].symbol
+	})
+
+	cyclomaticThreshold := r.cyclomaticThreshold()
+	cognitiveThreshold := r.cognitiveThreshold()
+
+	diff := &BaselineDiff{
+		PrevSessionID:      prev.SessionID,
+		CurrSessionID:      curr.SessionID,
+		TotalFilesBefore:   prev.TotalFiles,
+		TotalFilesAfter:    curr.TotalFiles,
+		TotalSymbolsBefore: prev.TotalSymbols,
+		TotalSymbolsAfter:  curr.TotalSymbols,
+	}
+
+	var cycBefore, cycAfter, cogBefore, cogAfter int
+	for _, k := range keys {
+		before, hadBefore := prevByKey[k]
+		after, hadAfter := currByKey[k]
+
+		sym := SymbolDiff{File: k.file, Symbol: k.symbol}
+		switch {
+		case !hadBefore:
+			sym.Status = SymbolNew
+			sym.CyclomaticAfter = after.CyclomaticComplexity
+			sym.CognitiveAfter = after.CognitiveComplexity
+			diff.NewCount++
+		case !hadAfter:
+			sym.Status = SymbolRemoved
+			sym.CyclomaticBefore = before.CyclomaticComplexity
+			sym.CognitiveBefore = before.CognitiveComplexity
+			diff.RemovedCount++
+		default:
+			sym.CyclomaticBefore = before.CyclomaticComplexity
+			sym.CyclomaticAfter = after.CyclomaticComplexity
+			sym.CognitiveBefore = before.CognitiveComplexity
+			sym.CognitiveAfter = after.CognitiveComplexity
+
+			deltaCyc := after.CyclomaticComplexity - before.CyclomaticComplexity
+			deltaCog := after.CognitiveComplexity - before.CognitiveComplexity
+
+			switch {
+			case deltaCyc >= cyclomaticThreshold || deltaCog >= cognitiveThreshold:
+				sym.Status = SymbolWorsened
+				diff.WorsenedCount++
+			case deltaCyc <= -cyclomaticThreshold || deltaCog <= -cognitiveThreshold:
+				sym.Status = SymbolImproved
+				diff.ImprovedCount++
+			default:
+				sym.Status = SymbolUnchanged
+				diff.UnchangedCount++
+			}
+		}
+
+		if hadBefore {
+			cycBefore += before.CyclomaticComplexity
+			cogBefore += before.CognitiveComplexity
+		}
+		if hadAfter {
+			cycAfter += after.CyclomaticComplexity
+			cogAfter += after.CognitiveComplexity
+		}
+
+		diff.Symbols = append(diff.Symbols, sym)
+	}
+
+	if len(prev.Hotspots) > 0 {
+		diff.AvgCyclomaticBefore = float64(cycBefore) / float64(len(prev.Hotspots))
+		diff.AvgCognitiveBefore = float64(cogBefore) / float64(len(prev.Hotspots))
+	}
+	if len(curr.Hotspots) > 0 {
+		diff.AvgCyclomaticAfter = float64(cycAfter) / float64(len(curr.Hotspots))
+		diff.AvgCognitiveAfter = float64(cogAfter) / float64(len(curr.Hotspots))
+	}
+
+	return diff, nil
+}
+
+// GenerateBaselineDiff writes a Markdown diff report and a machine-readable
+// JSON summary for diff, named after its curr session.
+func (r *Reporter) GenerateBaselineDiff(diff *BaselineDiff) error {
+	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	content := r.formatBaselineDiffMarkdown(diff)
+	mdPath := filepath.Join(r.outputDir, fmt.Sprintf("reducto-baseline-diff-%s.md", diff.CurrSessionID))
+	if err := os.WriteFile(mdPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline diff report: %w", err)
+	}
+
+	data, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline diff: %w", err)
+	}
+	jsonPath := filepath.Join(r.outputDir, fmt.Sprintf("reducto-baseline-diff-%s.json", diff.CurrSessionID))
+	if err := os.WriteFile(jsonPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write baseline diff summary: %w", err)
+	}
+
+	return nil
+}
+
+// formatBaselineDiffMarkdown renders diff as a human-readable Markdown
+// regression report.
+func (r *Reporter) formatBaselineDiffMarkdown(diff *BaselineDiff) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# reducto Baseline Diff Report\n\n")
+	fmt.Fprintf(&b, "Previous session: %s\n", diff.PrevSessionID)
+	fmt.Fprintf(&b, "Current session: %s\n\n", diff.CurrSessionID)
+
+	fmt.Fprintf(&b, "## Summary\n\n")
+	fmt.Fprintf(&b, "| Metric | Before | After |\n")
+	fmt.Fprintf(&b, "|---|---|---|\n")
+	fmt.Fprintf(&b, "| Total Files | %d | %d |\n", diff.TotalFilesBefore, diff.TotalFilesAfter)
+	fmt.Fprintf(&b, "| Total Symbols | %d | %d |\n", diff.TotalSymbolsBefore, diff.TotalSymbolsAfter)
+	fmt.Fprintf(&b, "| Avg Cyclomatic Complexity | %.1f | %.1f |\n", diff.AvgCyclomaticBefore, diff.AvgCyclomaticAfter)
+	fmt.Fprintf(&b, "| Avg Cognitive Complexity | %.1f | %.1f |\n\n", diff.AvgCognitiveBefore, diff.AvgCognitiveAfter)
+
+	fmt.Fprintf(&b, "New: %d  Removed: %d  Worsened: %d  Improved: %d  Unchanged: %d\n\n",
+		diff.NewCount, diff.RemovedCount, diff.WorsenedCount, diff.ImprovedCount, diff.UnchangedCount)
+
+	fmt.Fprintf(&b, "## Symbols\n\n")
+	if len(diff.Symbols) == 0 {
+		fmt.Fprintf(&b, "No hotspots in either baseline.\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "| File | Symbol | Status | Cyclomatic | Cognitive |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|\n")
+	for _, s := range diff.Symbols {
+		fmt.Fprintf(&b, "| %s | %s | %s | %d -> %d | %d -> %d |\n",
+			s.File, s.Symbol, s.Status, s.CyclomaticBefore, s.CyclomaticAfter, s.CognitiveBefore, s.CognitiveAfter)
+	}
+
+	return b.String()
+}
+
+// LoadBaseline reads a previously generated baseline back, preferring the
+// JSON form written alongside the Markdown report. An empty sessionID loads
+// the most recently generated baseline. When a baseline predates JSON
+// persistence, LoadBaseline falls back to reconstructing it from the
+// Markdown report's tables.
+func (r *Reporter) LoadBaseline(sessionID string) (*BaselineResult, error) {
+	entries, err := os.ReadDir(r.outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output dir: %w", err)
+	}
+
+	isBaselineJSON := func(name string) bool {
+		return strings.HasPrefix(name, "reducto-baseline-") && strings.HasSuffix(name, ".json") && !strings.Contains(name, "-diff-")
+	}
+	isBaselineMarkdown := func(name string) bool {
+		return strings.HasPrefix(name, "reducto-baseline-") && strings.HasSuffix(name, ".md") && !strings.Contains(name, "-diff-")
+	}
+
+	var jsonCandidates, mdCandidates []os.DirEntry
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		switch {
+		case isBaselineJSON(name):
+			if sessionID == "" || name == fmt.Sprintf("reducto-baseline-%s.json", sessionID) {
+				jsonCandidates = append(jsonCandidates, entry)
+			}
+		case isBaselineMarkdown(name):
+			if sessionID == "" || name == fmt.Sprintf("reducto-baseline-%s.md", sessionID) {
+				mdCandidates = append(mdCandidates, entry)
+			}
+		}
+	}
+
+	newestOf := func(candidates []os.DirEntry) os.DirEntry {
+		sort.Slice(candidates, func(i, j int) bool {
+			infoI, errI := candidates[i].Info()
+			infoJ, errJ := candidates[j].Info()
+			if errI != nil || errJ != nil {
+				return false
+			}
+			return infoI.ModTime().After(infoJ.ModTime())
+		})
+		return candidates[0]
+	}
+
+	if len(jsonCandidates) > 0 {
+		entry := newestOf(jsonCandidates)
+		data, err := os.ReadFile(filepath.Join(r.outputDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read baseline: %w", err)
+		}
+		var result BaselineResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse baseline: %w", err)
+		}
+		return &result, nil
+	}
+
+	if len(mdCandidates) > 0 {
+		entry := newestOf(mdCandidates)
+		data, err := os.ReadFile(filepath.Join(r.outputDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read baseline: %w", err)
+		}
+		return migrateBaselineMarkdown(data)
+	}
+
+	return nil, fmt.Errorf("no baseline found for session %q", sessionID)
+}
+
+var (
+	baselineSummaryLineRe = regexp.MustCompile(`^\|\s*Total (Files|Symbols)\s*\|\s*(\d+)\s*\|`)
+	baselineHotspotLineRe = regexp.MustCompile(`^\|\s*([^|]+?)\s*\|\s*(\d+)\s*\|\s*([^|]+?)\s*\|\s*(\d+)\s*\|\s*(\d+)\s*\|$`)
+)
+
+// migrateBaselineMarkdown reconstructs a BaselineResult from a Markdown
+// baseline report, for baselines persisted before JSON was introduced.
+func migrateBaselineMarkdown(data []byte) (*BaselineResult, error) {
+	result := &BaselineResult{}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if m := baselineSummaryLineRe.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.Atoi(m[2])
+			if m[1] == "Files" {
+				result.TotalFiles = n
+			} else {
+				result.TotalSymbols = n
+			}
+			continue
+		}
+
+		if m := baselineHotspotLineRe.FindStringSubmatch(line); m != nil {
+			lineNum, err := strconv.Atoi(strings.TrimSpace(m[2]))
+			if err != nil {
+				continue
+			}
+			cyclomatic, err := strconv.Atoi(strings.TrimSpace(m[4]))
+			if err != nil {
+				continue
+			}
+			cognitive, err := strconv.Atoi(strings.TrimSpace(m[5]))
+			if err != nil {
+				continue
+			}
+			result.Hotspots = append(result.Hotspots, ComplexityHotspot{
+				File:                 strings.TrimSpace(m[1]),
+				Line:                 lineNum,
+				Symbol:               strings.TrimSpace(m[3]),
+				CyclomaticComplexity: cyclomatic,
+				CognitiveComplexity:  cognitive,
+			})
+		}
+	}
+
+	return result, nil
+}
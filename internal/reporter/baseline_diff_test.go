@@ -0,0 +1,169 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestCompareBaselines(t *testing.T) {
+	cfg := &models.Config{
+		ComplexityThresholds: models.ComplexityThresholds{
+			CyclomaticComplexity: 2,
+			CognitiveComplexity:  2,
+		},
+	}
+	r := New(func() *models.Config { return cfg })
+
+	prev := &BaselineResult{
+		SessionID:    "prev",
+		TotalFiles:   2,
+		TotalSymbols: 3,
+		Hotspots: []ComplexityHotspot{
+			{File: "a.py", Symbol: "worsens", CyclomaticComplexity: 10, CognitiveComplexity: 10},
+			{File: "a.py", Symbol: "improves", CyclomaticComplexity: 10, CognitiveComplexity: 10},
+			{File: "a.py", Symbol: "stable", CyclomaticComplexity: 10, CognitiveComplexity: 10},
+			{File: "a.py", Symbol: "removed", CyclomaticComplexity: 5, CognitiveComplexity: 5},
+		},
+	}
+	curr := &BaselineResult{
+		SessionID:    "curr",
+		TotalFiles:   2,
+		TotalSymbols: 4,
+		Hotspots: []ComplexityHotspot{
+			{File: "a.py", Symbol: "worsens", CyclomaticComplexity: 15, CognitiveComplexity: 10},
+			{File: "a.py", Symbol: "improves", CyclomaticComplexity: 7, CognitiveComplexity: 10},
+			{File: "a.py", Symbol: "stable", CyclomaticComplexity: 10, CognitiveComplexity: 10},
+			{File: "a.py", Symbol: "added", CyclomaticComplexity: 8, CognitiveComplexity: 8},
+		},
+	}
+
+	diff, err := r.CompareBaselines(prev, curr)
+	if err != nil {
+		t.Fatalf("CompareBaselines returned error: %v", err)
+	}
+
+	if diff.NewCount != 1 {
+		t.Errorf("expected 1 new symbol, got %d", diff.NewCount)
+	}
+	if diff.RemovedCount != 1 {
+		t.Errorf("expected 1 removed symbol, got %d", diff.RemovedCount)
+	}
+	if diff.WorsenedCount != 1 {
+		t.Errorf("expected 1 worsened symbol, got %d", diff.WorsenedCount)
+	}
+	if diff.ImprovedCount != 1 {
+		t.Errorf("expected 1 improved symbol, got %d", diff.ImprovedCount)
+	}
+	if diff.UnchangedCount != 1 {
+		t.Errorf("expected 1 unchanged symbol, got %d", diff.UnchangedCount)
+	}
+}
+
+func TestCompareBaselinesRequiresBothSides(t *testing.T) {
+	r := New(func() *models.Config { return &models.Config{} })
+	if _, err := r.CompareBaselines(nil, &BaselineResult{}); err == nil {
+		t.Error("expected error for nil prev baseline")
+	}
+	if _, err := r.CompareBaselines(&BaselineResult{}, nil); err == nil {
+		t.Error("expected error for nil curr baseline")
+	}
+}
+
+func TestGenerateBaselineDiff(t *testing.T) {
+	tmpDir := t.TempDir()
+	r := New(func() *models.Config { return &models.Config{} })
+	r.outputDir = filepath.Join(tmpDir, ".reducto")
+
+	diff := &BaselineDiff{
+		PrevSessionID: "prev",
+		CurrSessionID: "curr",
+		NewCount:      1,
+		Symbols: []SymbolDiff{
+			{File: "a.py", Symbol: "worsens", Status: SymbolWorsened, CyclomaticBefore: 10, CyclomaticAfter: 15},
+		},
+	}
+
+	if err := r.GenerateBaselineDiff(diff); err != nil {
+		t.Fatalf("GenerateBaselineDiff returned error: %v", err)
+	}
+
+	mdPath := filepath.Join(r.outputDir, "reducto-baseline-diff-curr.md")
+	content, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatalf("expected markdown diff report to exist: %v", err)
+	}
+	if !strings.Contains(string(content), "worsens") {
+		t.Error("expected markdown diff to mention the worsened symbol")
+	}
+
+	jsonPath := filepath.Join(r.outputDir, "reducto-baseline-diff-curr.json")
+	if _, err := os.ReadFile(jsonPath); err != nil {
+		t.Fatalf("expected JSON diff summary to exist: %v", err)
+	}
+}
+
+func TestGenerateBaselinePersistsJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	r := New(func() *models.Config { return &models.Config{} })
+	r.outputDir = filepath.Join(tmpDir, ".reducto")
+
+	result := &BaselineResult{
+		SessionID:    "sess1",
+		TotalFiles:   1,
+		TotalSymbols: 1,
+		Hotspots: []ComplexityHotspot{
+			{File: "a.py", Symbol: "foo", CyclomaticComplexity: 5, CognitiveComplexity: 5},
+		},
+	}
+	if err := r.GenerateBaseline(result); err != nil {
+		t.Fatalf("GenerateBaseline returned error: %v", err)
+	}
+
+	loaded, err := r.LoadBaseline("sess1")
+	if err != nil {
+		t.Fatalf("LoadBaseline returned error: %v", err)
+	}
+	if loaded.TotalFiles != 1 || len(loaded.Hotspots) != 1 {
+		t.Errorf("expected loaded baseline to match what was generated, got %+v", loaded)
+	}
+}
+
+func TestLoadBaselineMigratesFromMarkdown(t *testing.T) {
+	tmpDir := t.TempDir()
+	r := New(func() *models.Config { return &models.Config{} })
+	r.outputDir = filepath.Join(tmpDir, ".reducto")
+	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
+		t.Fatalf("failed to create output dir: %v", err)
+	}
+
+	md := "# reducto Baseline Analysis Report\n\n" +
+		"Session: legacy\n\n" +
+		"## Summary\n\n" +
+		"| Metric | Value |\n" +
+		"|---|---|\n" +
+		"| Total Files | 3 |\n" +
+		"| Total Symbols | 7 |\n\n" +
+		"## Complexity Hotspots\n\n" +
+		"| File | Line | Symbol | Cyclomatic | Cognitive |\n" +
+		"|---|---|---|---|---|\n" +
+		"| legacy.py | 42 | old_func | 12 | 18 |\n"
+
+	if err := os.WriteFile(filepath.Join(r.outputDir, "reducto-baseline-legacy.md"), []byte(md), 0644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	loaded, err := r.LoadBaseline("legacy")
+	if err != nil {
+		t.Fatalf("LoadBaseline returned error: %v", err)
+	}
+	if loaded.TotalFiles != 3 || loaded.TotalSymbols != 7 {
+		t.Errorf("expected migrated totals 3/7, got %d/%d", loaded.TotalFiles, loaded.TotalSymbols)
+	}
+	if len(loaded.Hotspots) != 1 || loaded.Hotspots[0].Symbol != "old_func" {
+		t.Errorf("expected migrated hotspot old_func, got %+v", loaded.Hotspots)
+	}
+}
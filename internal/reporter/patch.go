@@ -0,0 +1,243 @@
+package reporter
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// defaultPatchContext is the number of unchanged lines kept around each
+// hunk of changes, matching git diff's own default.
+const defaultPatchContext = 3
+
+// hunk is one @@ -origStart,origCount +newStart,newCount @@ block of a
+// unified diff, carrying the context, removed, and added lines it covers.
+type hunk struct {
+	origStart, origCount int
+	newStart, newCount   int
+	lines                []lineChange
+}
+
+// GeneratePatch renders plan as a single git-apply-compatible unified
+// diff covering every changed file, in the same order as plan.Changes.
+func (r *Reporter) GeneratePatch(plan *models.RefactorPlan) ([]byte, error) {
+	var b strings.Builder
+	for _, change := range plan.Changes {
+		if change.Original == change.Modified {
+			continue
+		}
+		writeFilePatch(&b, change)
+	}
+	return []byte(b.String()), nil
+}
+
+// GeneratePatchFile writes plan's patch (see GeneratePatch) to
+// .reducto/reducto-dryrun-<sid>.patch.
+func (r *Reporter) GeneratePatchFile(plan *models.RefactorPlan) error {
+	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	data, err := r.GeneratePatch(plan)
+	if err != nil {
+		return fmt.Errorf("failed to generate patch: %w", err)
+	}
+
+	path := filepath.Join(r.outputDir, fmt.Sprintf("reducto-dryrun-%s.patch", plan.SessionID))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write patch: %w", err)
+	}
+
+	return nil
+}
+
+// writeFilePatch appends one file's "diff --git" block, including its
+// mode/index lines and every hunk, to b.
+func writeFilePatch(b *strings.Builder, change models.FileChange) {
+	isNew := change.Original == ""
+	isDeleted := change.Modified == ""
+
+	fmt.Fprintf(b, "diff --git a/%s b/%s\n", change.Path, change.Path)
+
+	oldSHA, newSHA := gitBlobSHA1(change.Original), gitBlobSHA1(change.Modified)
+	switch {
+	case isNew:
+		fmt.Fprintf(b, "new file mode 100644\n")
+		fmt.Fprintf(b, "index %s..%s\n", shortSHA(zeroSHA), shortSHA(newSHA))
+	case isDeleted:
+		fmt.Fprintf(b, "deleted file mode 100644\n")
+		fmt.Fprintf(b, "index %s..%s\n", shortSHA(oldSHA), shortSHA(zeroSHA))
+	default:
+		fmt.Fprintf(b, "index %s..%s 100644\n", shortSHA(oldSHA), shortSHA(newSHA))
+	}
+
+	oldPath, newPath := "a/"+change.Path, "b/"+change.Path
+	if isNew {
+		oldPath = "/dev/null"
+	}
+	if isDeleted {
+		newPath = "/dev/null"
+	}
+	fmt.Fprintf(b, "--- %s\n+++ %s\n", oldPath, newPath)
+
+	changes := diffLines(splitLines(change.Original), splitLines(change.Modified))
+	for _, h := range buildHunks(changes, defaultPatchContext) {
+		writeHunk(b, h)
+	}
+}
+
+// writeHunk appends one hunk's "@@ ... @@" header and its lines to b.
+func writeHunk(b *strings.Builder, h hunk) {
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", rangeSpec(h.origStart, h.origCount), rangeSpec(h.newStart, h.newCount))
+	for _, line := range h.lines {
+		b.WriteByte(line.kind)
+		b.WriteString(line.text)
+		b.WriteByte('\n')
+	}
+}
+
+// rangeSpec formats a hunk's start/count the way git does, omitting the
+// count when it's exactly 1.
+func rangeSpec(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// buildHunks groups changes into hunks of context-line-surrounded edits,
+// merging adjacent edits whose context windows overlap into a single hunk.
+func buildHunks(changes []lineChange, context int) []hunk {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	origLine := make([]int, len(changes))
+	newLine := make([]int, len(changes))
+	origCounter, newCounter := 0, 0
+	var dirty []int
+	for i, c := range changes {
+		switch c.kind {
+		case ' ':
+			origCounter++
+			newCounter++
+			origLine[i] = origCounter
+			newLine[i] = newCounter
+		case '-':
+			origCounter++
+			origLine[i] = origCounter
+			dirty = append(dirty, i)
+		case '+':
+			newCounter++
+			newLine[i] = newCounter
+			dirty = append(dirty, i)
+		}
+	}
+	if len(dirty) == 0 {
+		return nil
+	}
+
+	type window struct{ start, end int }
+	var windows []window
+	cur := window{start: clampMin(dirty[0]-context, 0), end: clampMax(dirty[0]+context, len(changes)-1)}
+	for _, d := range dirty[1:] {
+		start, end := clampMin(d-context, 0), clampMax(d+context, len(changes)-1)
+		if start <= cur.end+1 {
+			if end > cur.end {
+				cur.end = end
+			}
+			continue
+		}
+		windows = append(windows, cur)
+		cur = window{start: start, end: end}
+	}
+	windows = append(windows, cur)
+
+	hunks := make([]hunk, 0, len(windows))
+	for _, w := range windows {
+		hunks = append(hunks, buildHunk(changes, origLine, newLine, w.start, w.end))
+	}
+	return hunks
+}
+
+// buildHunk turns changes[start:end+1] into a single hunk, deriving its
+// @@ header from the orig/new line numbers recorded for that slice.
+func buildHunk(changes []lineChange, origLine, newLine []int, start, end int) hunk {
+	h := hunk{lines: changes[start : end+1]}
+
+	origCounterBefore := lastNonZero(origLine[:start])
+	newCounterBefore := lastNonZero(newLine[:start])
+
+	origStart, origCount := origCounterBefore, 0
+	newStart, newCount := newCounterBefore, 0
+	gotOrigStart, gotNewStart := false, false
+
+	for i := start; i <= end; i++ {
+		c := changes[i]
+		if c.kind != '+' {
+			origCount++
+			if !gotOrigStart {
+				origStart = origLine[i]
+				gotOrigStart = true
+			}
+		}
+		if c.kind != '-' {
+			newCount++
+			if !gotNewStart {
+				newStart = newLine[i]
+				gotNewStart = true
+			}
+		}
+	}
+
+	h.origStart, h.origCount = origStart, origCount
+	h.newStart, h.newCount = newStart, newCount
+	return h
+}
+
+func lastNonZero(nums []int) int {
+	for i := len(nums) - 1; i >= 0; i-- {
+		if nums[i] != 0 {
+			return nums[i]
+		}
+	}
+	return 0
+}
+
+func clampMin(v, min int) int {
+	if v < min {
+		return min
+	}
+	return v
+}
+
+func clampMax(v, max int) int {
+	if v > max {
+		return max
+	}
+	return v
+}
+
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// gitBlobSHA1 computes the SHA-1 a real `git hash-object` would assign to
+// content, so the patch's index line matches what git itself would show.
+func gitBlobSHA1(content string) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write([]byte(content))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// shortSHA abbreviates a hex SHA-1 to git's conventional 7 characters.
+func shortSHA(sha string) string {
+	if len(sha) < 7 {
+		return sha
+	}
+	return sha[:7]
+}
@@ -0,0 +1,207 @@
+package reporter
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// initGitRepo creates a git repo under dir seeded with files, and returns a
+// helper that shells out to `git apply --check` against patch data.
+func initGitRepo(t *testing.T, dir string, files map[string]string) func(patch []byte) error {
+	t.Helper()
+
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	runGit("init", "-q")
+	for path, content := range files {
+		full := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("failed to create dir for %s: %v", path, err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	runGit("add", "-A")
+	runGit("commit", "-q", "--allow-empty", "-m", "init")
+
+	return func(patch []byte) error {
+		patchPath := filepath.Join(t.TempDir(), "test.patch")
+		if err := os.WriteFile(patchPath, patch, 0644); err != nil {
+			return err
+		}
+		cmd := exec.Command("git", "apply", "--check", patchPath)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return &gitApplyError{out: string(out), err: err}
+		}
+		return nil
+	}
+}
+
+type gitApplyError struct {
+	out string
+	err error
+}
+
+func (e *gitApplyError) Error() string {
+	return e.err.Error() + ": " + e.out
+}
+
+func TestGeneratePatchAddition(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	check := initGitRepo(t, dir, nil)
+
+	r := New(func() *models.Config { return &models.Config{} })
+	plan := &models.RefactorPlan{
+		SessionID: "add1",
+		Changes: []models.FileChange{
+			{Path: "new.txt", Original: "", Modified: "line1\nline2\nline3\n"},
+		},
+	}
+
+	patch, err := r.GeneratePatch(plan)
+	if err != nil {
+		t.Fatalf("GeneratePatch returned error: %v", err)
+	}
+	if err := check(patch); err != nil {
+		t.Errorf("git apply --check failed: %v", err)
+	}
+}
+
+func TestGeneratePatchDeletion(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	check := initGitRepo(t, dir, map[string]string{"old.txt": "a\nb\nc\n"})
+
+	r := New(func() *models.Config { return &models.Config{} })
+	plan := &models.RefactorPlan{
+		SessionID: "del1",
+		Changes: []models.FileChange{
+			{Path: "old.txt", Original: "a\nb\nc\n", Modified: ""},
+		},
+	}
+
+	patch, err := r.GeneratePatch(plan)
+	if err != nil {
+		t.Fatalf("GeneratePatch returned error: %v", err)
+	}
+	if err := check(patch); err != nil {
+		t.Errorf("git apply --check failed: %v", err)
+	}
+}
+
+func TestGeneratePatchModification(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	var orig, modified string
+	for i := 1; i <= 20; i++ {
+		line := "line" + string(rune('0'+i%10))
+		orig += line + "\n"
+		if i == 10 {
+			modified += "CHANGED\n"
+		} else {
+			modified += line + "\n"
+		}
+	}
+
+	dir := t.TempDir()
+	check := initGitRepo(t, dir, map[string]string{"mod.txt": orig})
+
+	r := New(func() *models.Config { return &models.Config{} })
+	plan := &models.RefactorPlan{
+		SessionID: "mod1",
+		Changes: []models.FileChange{
+			{Path: "mod.txt", Original: orig, Modified: modified},
+		},
+	}
+
+	patch, err := r.GeneratePatch(plan)
+	if err != nil {
+		t.Fatalf("GeneratePatch returned error: %v", err)
+	}
+	if err := check(patch); err != nil {
+		t.Errorf("git apply --check failed: %v", err)
+	}
+}
+
+func TestGeneratePatchMixedMultiFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	check := initGitRepo(t, dir, map[string]string{
+		"keep_modified.txt": "foo\nbar\nbaz\n",
+		"to_delete.txt":     "gone\n",
+	})
+
+	r := New(func() *models.Config { return &models.Config{} })
+	plan := &models.RefactorPlan{
+		SessionID: "mixed1",
+		Changes: []models.FileChange{
+			{Path: "keep_modified.txt", Original: "foo\nbar\nbaz\n", Modified: "foo\nBAR\nbaz\n"},
+			{Path: "to_delete.txt", Original: "gone\n", Modified: ""},
+			{Path: "brand_new.txt", Original: "", Modified: "hello\n"},
+		},
+	}
+
+	patch, err := r.GeneratePatch(plan)
+	if err != nil {
+		t.Fatalf("GeneratePatch returned error: %v", err)
+	}
+	if err := check(patch); err != nil {
+		t.Errorf("git apply --check failed: %v", err)
+	}
+}
+
+func TestGeneratePatchFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	r := New(func() *models.Config { return &models.Config{} })
+	r.outputDir = filepath.Join(tmpDir, ".reducto")
+
+	plan := &models.RefactorPlan{
+		SessionID: "file1",
+		Changes: []models.FileChange{
+			{Path: "a.txt", Original: "old\n", Modified: "new\n"},
+		},
+	}
+
+	if err := r.GeneratePatchFile(plan); err != nil {
+		t.Fatalf("GeneratePatchFile returned error: %v", err)
+	}
+
+	path := filepath.Join(r.outputDir, "reducto-dryrun-file1.patch")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected patch file to exist: %v", err)
+	}
+	if len(content) == 0 {
+		t.Error("expected non-empty patch file")
+	}
+}
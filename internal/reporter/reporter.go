@@ -0,0 +1,428 @@
+// Package reporter renders refactor results, dry-run plans, and complexity
+// baselines as Markdown (for humans) and, when configured, SARIF 2.1.0 (for
+// CI code-scanning integrations).
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// ComplexityHotspot is one symbol flagged by a baseline scan for having
+// unusually high complexity relative to the rest of the codebase.
+type ComplexityHotspot struct {
+	File                 string
+	Line                 int
+	Symbol               string
+	CyclomaticComplexity int
+	CognitiveComplexity  int
+}
+
+// BaselineResult summarizes a full-repo complexity scan.
+type BaselineResult struct {
+	SessionID    string
+	TotalFiles   int
+	TotalSymbols int
+	Hotspots     []ComplexityHotspot
+}
+
+// Reporter writes Markdown and SARIF reports to outputDir, named after the
+// session that produced them.
+type Reporter struct {
+	cfg       func() *models.Config
+	outputDir string
+}
+
+// New returns a Reporter that writes into ".reducto" relative to the
+// current working directory. cfg is called fresh every time a report is
+// generated, so a live config.Loader's reloads are picked up without
+// restarting; callers that need a fixed snapshot for the life of one
+// operation should pass config.Static(snapshot) instead.
+func New(cfg func() *models.Config) *Reporter {
+	return &Reporter{
+		cfg:       cfg,
+		outputDir: ".reducto",
+	}
+}
+
+// Generate writes a Markdown report (and, when the config requests it, a
+// SARIF report) summarizing result.
+func (r *Reporter) Generate(result *models.RefactorResult) error {
+	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	report := &models.Report{
+		SessionID:     result.SessionID,
+		GeneratedAt:   time.Now(),
+		LOCBefore:     result.MetricsBefore.LinesOfCode,
+		LOCAfter:      result.MetricsAfter.LinesOfCode,
+		LOCReduced:    result.MetricsBefore.LinesOfCode - result.MetricsAfter.LinesOfCode,
+		FilesModified: r.extractModifiedFiles(result.Changes),
+		MetricsDelta: models.MetricsDelta{
+			CyclomaticComplexityDelta: result.MetricsAfter.CyclomaticComplexity - result.MetricsBefore.CyclomaticComplexity,
+			CognitiveComplexityDelta:  result.MetricsAfter.CognitiveComplexity - result.MetricsBefore.CognitiveComplexity,
+			MaintainabilityIndexDelta: result.MetricsAfter.MaintainabilityIndex - result.MetricsBefore.MaintainabilityIndex,
+		},
+	}
+
+	content := r.formatMarkdown(report, result)
+	path := filepath.Join(r.outputDir, fmt.Sprintf("reducto-report-%s.md", result.SessionID))
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if cfg := r.cfg(); cfg != nil && cfg.OutputFormat == "sarif" {
+		sarif, err := r.formatSARIFReport(report, result)
+		if err != nil {
+			return fmt.Errorf("failed to format SARIF report: %w", err)
+		}
+		sarifPath := filepath.Join(r.outputDir, fmt.Sprintf("reducto-report-%s.sarif", result.SessionID))
+		if err := os.WriteFile(sarifPath, sarif, 0644); err != nil {
+			return fmt.Errorf("failed to write SARIF report: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GenerateBaseline writes a Markdown report (and, when the config requests
+// it, a SARIF report) summarizing a complexity baseline scan.
+func (r *Reporter) GenerateBaseline(result *BaselineResult) error {
+	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	sessionID := result.SessionID
+	if sessionID == "" {
+		sessionID = strconv.FormatInt(time.Now().UnixNano(), 10)
+		result.SessionID = sessionID
+	}
+
+	content := r.formatBaselineMarkdown(sessionID, result)
+	path := filepath.Join(r.outputDir, fmt.Sprintf("reducto-baseline-%s.md", sessionID))
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline report: %w", err)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+	jsonPath := filepath.Join(r.outputDir, fmt.Sprintf("reducto-baseline-%s.json", sessionID))
+	if err := os.WriteFile(jsonPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	if cfg := r.cfg(); cfg != nil && cfg.OutputFormat == "sarif" {
+		sarif, err := r.formatSARIF(result)
+		if err != nil {
+			return fmt.Errorf("failed to format SARIF baseline: %w", err)
+		}
+		sarifPath := filepath.Join(r.outputDir, fmt.Sprintf("reducto-baseline-%s.sarif", sessionID))
+		if err := os.WriteFile(sarifPath, sarif, 0644); err != nil {
+			return fmt.Errorf("failed to write SARIF baseline: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GenerateDryRun writes a Markdown report describing a not-yet-applied
+// RefactorPlan, so a user can review command's proposed changes to path
+// before approving them.
+func (r *Reporter) GenerateDryRun(plan *models.RefactorPlan, command, path string) error {
+	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output dir: %w", err)
+	}
+
+	content := r.formatDryRunMarkdown(plan, command, path)
+	reportPath := filepath.Join(r.outputDir, fmt.Sprintf("reducto-dryrun-%s.md", plan.SessionID))
+	if err := os.WriteFile(reportPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write dry-run report: %w", err)
+	}
+
+	return nil
+}
+
+// Load verifies that a previously generated report exists, so callers can
+// surface a clear error before trying to open it. An empty sessionID loads
+// the most recently generated report.
+func (r *Reporter) Load(sessionID string) error {
+	entries, err := os.ReadDir(r.outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read output dir: %w", err)
+	}
+
+	var candidates []os.DirEntry
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "reducto-report-") {
+			continue
+		}
+		if sessionID != "" && entry.Name() != fmt.Sprintf("reducto-report-%s.md", sessionID) {
+			continue
+		}
+		candidates = append(candidates, entry)
+	}
+
+	if len(candidates) == 0 {
+		return fmt.Errorf("no report found for session %q", sessionID)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		infoI, errI := candidates[i].Info()
+		infoJ, errJ := candidates[j].Info()
+		if errI != nil || errJ != nil {
+			return false
+		}
+		return infoI.ModTime().After(infoJ.ModTime())
+	})
+
+	_, err = os.ReadFile(filepath.Join(r.outputDir, candidates[0].Name()))
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+
+	return nil
+}
+
+// formatMarkdown renders report and result as a human-readable Markdown
+// compression report.
+func (r *Reporter) formatMarkdown(report *models.Report, result *models.RefactorResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# reducto Compression Report\n\n")
+	fmt.Fprintf(&b, "Session: %s\n", report.SessionID)
+	fmt.Fprintf(&b, "Generated: %s\n\n", report.GeneratedAt.Format(time.RFC3339))
+
+	fmt.Fprintf(&b, "## Summary\n\n")
+	fmt.Fprintf(&b, "| Metric | Before | After | Delta |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| Lines of Code | %d | %d | %d |\n", report.LOCBefore, report.LOCAfter, report.LOCReduced)
+	fmt.Fprintf(&b, "| Cyclomatic Complexity | %d | %d | %d |\n",
+		result.MetricsBefore.CyclomaticComplexity, result.MetricsAfter.CyclomaticComplexity, report.MetricsDelta.CyclomaticComplexityDelta)
+	fmt.Fprintf(&b, "| Cognitive Complexity | %d | %d | %d |\n",
+		result.MetricsBefore.CognitiveComplexity, result.MetricsAfter.CognitiveComplexity, report.MetricsDelta.CognitiveComplexityDelta)
+	fmt.Fprintf(&b, "| Maintainability Index | %.1f | %.1f | %.1f |\n\n",
+		result.MetricsBefore.MaintainabilityIndex, result.MetricsAfter.MaintainabilityIndex, report.MetricsDelta.MaintainabilityIndexDelta)
+
+	fmt.Fprintf(&b, "## Changes\n\n")
+	for _, change := range result.Changes {
+		fmt.Fprintf(&b, "### %s\n\n", change.Path)
+		fmt.Fprintf(&b, "%s\n\n", change.Description)
+		diff := r.generateDiff(change.Original, change.Modified)
+		if diff != "" {
+			fmt.Fprintf(&b, "```diff\n%s```\n\n", diff)
+		}
+	}
+
+	return b.String()
+}
+
+// formatBaselineMarkdown renders result as a human-readable Markdown
+// baseline report, labeled with sessionID.
+func (r *Reporter) formatBaselineMarkdown(sessionID string, result *BaselineResult) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# reducto Baseline Analysis Report\n\n")
+	fmt.Fprintf(&b, "Session: %s\n\n", sessionID)
+
+	fmt.Fprintf(&b, "## Summary\n\n")
+	fmt.Fprintf(&b, "| Metric | Value |\n")
+	fmt.Fprintf(&b, "|---|---|\n")
+	fmt.Fprintf(&b, "| Total Files | %d |\n", result.TotalFiles)
+	fmt.Fprintf(&b, "| Total Symbols | %d |\n\n", result.TotalSymbols)
+
+	fmt.Fprintf(&b, "## Complexity Hotspots\n\n")
+	if len(result.Hotspots) == 0 {
+		fmt.Fprintf(&b, "No complexity hotspots found.\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "| File | Line | Symbol | Cyclomatic | Cognitive |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|\n")
+	for _, h := range result.Hotspots {
+		fmt.Fprintf(&b, "| %s | %d | %s | %d | %d |\n", h.File, h.Line, h.Symbol, h.CyclomaticComplexity, h.CognitiveComplexity)
+	}
+
+	return b.String()
+}
+
+// formatDryRunMarkdown renders plan as a human-readable Markdown preview of
+// what command would change under path, without having applied anything.
+func (r *Reporter) formatDryRunMarkdown(plan *models.RefactorPlan, command, path string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# reducto Dry-Run Report\n\n")
+	fmt.Fprintf(&b, "**DRY RUN MODE** - no changes have been applied.\n\n")
+	fmt.Fprintf(&b, "Command: %s\n", command)
+	fmt.Fprintf(&b, "Path: %s\n", path)
+	fmt.Fprintf(&b, "Session: %s\n\n", plan.SessionID)
+	fmt.Fprintf(&b, "%s\n\n", plan.Description)
+
+	fmt.Fprintf(&b, "Estimated LOC change: %s\n\n", r.estimateLOCChange(plan.Changes))
+
+	fmt.Fprintf(&b, "## Proposed Changes\n\n")
+	if len(plan.Changes) == 0 {
+		fmt.Fprintf(&b, "No changes proposed.\n")
+		return b.String()
+	}
+
+	for _, change := range plan.Changes {
+		fmt.Fprintf(&b, "### %s\n\n", change.Path)
+		fmt.Fprintf(&b, "%s\n\n", change.Description)
+
+		oldPath, newPath := "a/"+change.Path, "b/"+change.Path
+		if change.Original == "" {
+			oldPath = "/dev/null"
+		}
+		if change.Modified == "" {
+			newPath = "/dev/null"
+		}
+
+		fmt.Fprintf(&b, "```diff\n--- %s\n+++ %s\n", oldPath, newPath)
+		fmt.Fprint(&b, r.generateDiff(change.Original, change.Modified))
+		fmt.Fprintf(&b, "```\n\n")
+	}
+
+	return b.String()
+}
+
+// extractModifiedFiles returns the unique file paths touched by changes,
+// in first-seen order.
+func (r *Reporter) extractModifiedFiles(changes []models.FileChange) []string {
+	seen := make(map[string]bool)
+	files := make([]string, 0, len(changes))
+	for _, c := range changes {
+		if seen[c.Path] {
+			continue
+		}
+		seen[c.Path] = true
+		files = append(files, c.Path)
+	}
+	return files
+}
+
+// estimateLOCChange summarizes the net lines added/removed across changes
+// as a short human-readable string, e.g. "+12 lines" or "+4/-9 lines".
+func (r *Reporter) estimateLOCChange(changes []models.FileChange) string {
+	added, removed := 0, 0
+	for _, c := range changes {
+		origLines, modLines := countLines(c.Original), countLines(c.Modified)
+		switch {
+		case c.Original == "":
+			added += modLines
+		case c.Modified == "":
+			removed += origLines
+		case modLines >= origLines:
+			added += modLines - origLines
+		default:
+			removed += origLines - modLines
+		}
+	}
+
+	switch {
+	case added == 0 && removed == 0:
+		return "0 lines changed"
+	case added > 0 && removed == 0:
+		return fmt.Sprintf("+%d lines", added)
+	case removed > 0 && added == 0:
+		return fmt.Sprintf("-%d lines", removed)
+	default:
+		return fmt.Sprintf("+%d/-%d lines", added, removed)
+	}
+}
+
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	return strings.Count(s, "\n")
+}
+
+// generateDiff renders a unified-style, line-level diff between original
+// and modified, or "" when they're identical.
+func (r *Reporter) generateDiff(original, modified string) string {
+	if original == modified {
+		return ""
+	}
+
+	origLines := splitLines(original)
+	modLines := splitLines(modified)
+
+	var b strings.Builder
+	for _, change := range diffLines(origLines, modLines) {
+		b.WriteByte(change.kind)
+		b.WriteString(change.text)
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+type lineChange struct {
+	kind byte
+	text string
+}
+
+// diffLines computes a minimal line-level edit script between a and b via
+// a classic LCS dynamic-program filled in back to front, then walks the
+// table front to back to emit unchanged, removed, and added lines in order.
+func diffLines(a, b []string) []lineChange {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	changes := make([]lineChange, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			changes = append(changes, lineChange{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			changes = append(changes, lineChange{'-', a[i]})
+			i++
+		default:
+			changes = append(changes, lineChange{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		changes = append(changes, lineChange{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		changes = append(changes, lineChange{'+', b[j]})
+	}
+
+	return changes
+}
@@ -12,12 +12,12 @@ import (
 
 func TestNew(t *testing.T) {
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 
 	if r == nil {
 		t.Fatal("New returned nil")
 	}
-	if r.cfg != cfg {
+	if r.cfg() != cfg {
 		t.Error("config not set correctly")
 	}
 	if r.outputDir != ".reducto" {
@@ -29,7 +29,7 @@ func TestGenerate(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 	r.outputDir = filepath.Join(tmpDir, ".reducto")
 
 	result := &models.RefactorResult{
@@ -84,7 +84,7 @@ func TestGenerateBaseline(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 	r.outputDir = filepath.Join(tmpDir, ".reducto")
 
 	result := &BaselineResult{
@@ -138,7 +138,7 @@ func TestGenerateBaseline(t *testing.T) {
 
 func TestFormatBaselineMarkdown(t *testing.T) {
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 
 	result := &BaselineResult{
 		TotalFiles:   5,
@@ -175,7 +175,7 @@ func TestFormatBaselineMarkdown(t *testing.T) {
 
 func TestFormatMarkdown(t *testing.T) {
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 
 	report := &models.Report{
 		SessionID:     "test-123",
@@ -233,7 +233,7 @@ func TestFormatMarkdown(t *testing.T) {
 
 func TestExtractModifiedFiles(t *testing.T) {
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 
 	changes := []models.FileChange{
 		{Path: "test.py"},
@@ -259,7 +259,7 @@ func TestExtractModifiedFiles(t *testing.T) {
 
 func TestGenerateDiff(t *testing.T) {
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 
 	tests := []struct {
 		name     string
@@ -315,7 +315,7 @@ func TestLoad(t *testing.T) {
 		tmpDir := t.TempDir()
 
 		cfg := &models.Config{}
-		r := New(cfg)
+		r := New(func() *models.Config { return cfg })
 		r.outputDir = tmpDir
 
 		err := r.Load("")
@@ -335,7 +335,7 @@ func TestLoad(t *testing.T) {
 		}
 
 		cfg := &models.Config{}
-		r := New(cfg)
+		r := New(func() *models.Config { return cfg })
 		r.outputDir = tmpDir
 
 		err = r.Load("test-123")
@@ -364,7 +364,7 @@ func TestLoad(t *testing.T) {
 		}
 
 		cfg := &models.Config{}
-		r := New(cfg)
+		r := New(func() *models.Config { return cfg })
 		r.outputDir = tmpDir
 
 		err = r.Load("")
@@ -378,7 +378,7 @@ func TestGenerateDryRun(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 	r.outputDir = filepath.Join(tmpDir, ".reducto")
 
 	plan := &models.RefactorPlan{
@@ -435,7 +435,7 @@ func TestGenerateDryRun(t *testing.T) {
 
 func TestFormatDryRunMarkdown(t *testing.T) {
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 
 	t.Run("with changes", func(t *testing.T) {
 		plan := &models.RefactorPlan{
@@ -541,7 +541,7 @@ func TestFormatDryRunMarkdown(t *testing.T) {
 
 func TestEstimateLOCChange(t *testing.T) {
 	cfg := &models.Config{}
-	r := New(cfg)
+	r := New(func() *models.Config { return cfg })
 
 	tests := []struct {
 		name        string
@@ -0,0 +1,296 @@
+package reporter
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+const sarifVersion = "2.1.0"
+const sarifInformationURI = "https://github.com/alexkarsten/reducto"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Results     []sarifResult     `json:"results"`
+	Invocations []sarifInvocation `json:"invocations"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 sarifMessage       `json:"help"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful bool              `json:"executionSuccessful"`
+	StartTimeUTC        string            `json:"startTimeUtc"`
+	EndTimeUTC          string            `json:"endTimeUtc"`
+	Properties          map[string]string `json:"properties,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion  `json:"deletedRegion"`
+	InsertedContent sarifMessage `json:"insertedContent"`
+}
+
+// sarifRules describes the metrics reducto can flag, shared by both the
+// baseline and refactor-result SARIF runs.
+func (r *Reporter) sarifRules() []sarifRule {
+	return []sarifRule{
+		{
+			ID:               "reducto/cyclomatic-complexity",
+			Name:             "CyclomaticComplexity",
+			ShortDescription: sarifMessage{Text: "Cyclomatic complexity exceeds the configured threshold"},
+			Help:             sarifMessage{Text: "The number of independent paths through this symbol is high enough to make it hard to test and reason about. Consider extracting sub-functions or simplifying branching."},
+			DefaultConfiguration: sarifConfiguration{
+				Level: "warning",
+			},
+		},
+		{
+			ID:               "reducto/cognitive-complexity",
+			Name:             "CognitiveComplexity",
+			ShortDescription: sarifMessage{Text: "Cognitive complexity exceeds the configured threshold"},
+			Help:             sarifMessage{Text: "This symbol nests and branches enough that it's hard to read in one pass. Consider flattening conditionals or splitting it into smaller pieces."},
+			DefaultConfiguration: sarifConfiguration{
+				Level: "warning",
+			},
+		},
+		{
+			ID:               "reducto/refactor-change",
+			Name:             "RefactorChange",
+			ShortDescription: sarifMessage{Text: "reducto proposed or applied a change to this file"},
+			Help:             sarifMessage{Text: "Review the replacement content in this result's fix before accepting it."},
+			DefaultConfiguration: sarifConfiguration{
+				Level: "note",
+			},
+		},
+	}
+}
+
+// cyclomaticThreshold and cognitiveThreshold fall back to sane defaults
+// when the config leaves ComplexityThresholds unset, so a zero-value
+// Config doesn't flag every symbol as critically complex.
+func (r *Reporter) cyclomaticThreshold() int {
+	if cfg := r.cfg(); cfg != nil && cfg.ComplexityThresholds.CyclomaticComplexity > 0 {
+		return cfg.ComplexityThresholds.CyclomaticComplexity
+	}
+	return 10
+}
+
+func (r *Reporter) cognitiveThreshold() int {
+	if cfg := r.cfg(); cfg != nil && cfg.ComplexityThresholds.CognitiveComplexity > 0 {
+		return cfg.ComplexityThresholds.CognitiveComplexity
+	}
+	return 15
+}
+
+// sarifLevel maps a metric value against threshold: "error" at 2x
+// threshold or above, "warning" above threshold, "note" otherwise.
+func sarifLevel(value, threshold int) string {
+	switch {
+	case value >= threshold*2:
+		return "error"
+	case value > threshold:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// formatSARIF renders result as a SARIF 2.1.0 log with two results per
+// complexity hotspot, one per metric, each leveled by sarifLevel against
+// that metric's configured threshold.
+func (r *Reporter) formatSARIF(result *BaselineResult) ([]byte, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	cyclomaticThreshold := r.cyclomaticThreshold()
+	cognitiveThreshold := r.cognitiveThreshold()
+
+	var results []sarifResult
+	for _, h := range result.Hotspots {
+		results = append(results, sarifResult{
+			RuleID: "reducto/cyclomatic-complexity",
+			Level:  sarifLevel(h.CyclomaticComplexity, cyclomaticThreshold),
+			Message: sarifMessage{
+				Text: sarifHotspotMessage(h, "cyclomatic", h.CyclomaticComplexity),
+			},
+			Locations: []sarifLocation{sarifFileLocation(h.File, h.Line)},
+		})
+		results = append(results, sarifResult{
+			RuleID: "reducto/cognitive-complexity",
+			Level:  sarifLevel(h.CognitiveComplexity, cognitiveThreshold),
+			Message: sarifMessage{
+				Text: sarifHotspotMessage(h, "cognitive", h.CognitiveComplexity),
+			},
+			Locations: []sarifLocation{sarifFileLocation(h.File, h.Line)},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "reducto",
+						InformationURI: sarifInformationURI,
+						Rules:          r.sarifRules(),
+					},
+				},
+				Results: results,
+				Invocations: []sarifInvocation{
+					{
+						ExecutionSuccessful: true,
+						StartTimeUTC:        now,
+						EndTimeUTC:          now,
+						Properties:          map[string]string{"sessionId": result.SessionID},
+					},
+				},
+			},
+		},
+	}
+
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// formatSARIFReport renders report/result as a SARIF 2.1.0 log, one result
+// per FileChange carrying its replacement content as a fix.
+func (r *Reporter) formatSARIFReport(report *models.Report, result *models.RefactorResult) ([]byte, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	results := make([]sarifResult, 0, len(result.Changes))
+	for _, change := range result.Changes {
+		results = append(results, sarifResult{
+			RuleID:    "reducto/refactor-change",
+			Level:     "note",
+			Message:   sarifMessage{Text: sarifChangeMessage(change, report.MetricsDelta)},
+			Locations: []sarifLocation{sarifFileLocation(change.Path, 1)},
+			Fixes: []sarifFix{
+				{
+					Description: sarifMessage{Text: change.Description},
+					ArtifactChanges: []sarifArtifactChange{
+						{
+							ArtifactLocation: sarifArtifactLocation{URI: change.Path},
+							Replacements: []sarifReplacement{
+								{
+									DeletedRegion:   sarifRegion{StartLine: 1},
+									InsertedContent: sarifMessage{Text: change.Modified},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "reducto",
+						InformationURI: sarifInformationURI,
+						Rules:          r.sarifRules(),
+					},
+				},
+				Results: results,
+				Invocations: []sarifInvocation{
+					{
+						ExecutionSuccessful: true,
+						StartTimeUTC:        now,
+						EndTimeUTC:          now,
+						Properties:          map[string]string{"sessionId": report.SessionID},
+					},
+				},
+			},
+		},
+	}
+
+	return json.MarshalIndent(log, "", "  ")
+}
+
+func sarifFileLocation(path string, line int) sarifLocation {
+	return sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: path},
+			Region:           sarifRegion{StartLine: line},
+		},
+	}
+}
+
+func sarifHotspotMessage(h ComplexityHotspot, metric string, value int) string {
+	return h.Symbol + " in " + h.File + " has " + metric + " complexity " + strconv.Itoa(value)
+}
+
+func sarifChangeMessage(change models.FileChange, delta models.MetricsDelta) string {
+	return change.Path + ": " + change.Description +
+		" (cyclomatic delta " + strconv.Itoa(delta.CyclomaticComplexityDelta) +
+		", cognitive delta " + strconv.Itoa(delta.CognitiveComplexityDelta) + ")"
+}
@@ -0,0 +1,106 @@
+package reporter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestFormatSARIF(t *testing.T) {
+	cfg := &models.Config{OutputFormat: "sarif"}
+	r := New(func() *models.Config { return cfg })
+
+	result := &BaselineResult{
+		SessionID:    "sarif-session",
+		TotalFiles:   2,
+		TotalSymbols: 3,
+		Hotspots: []ComplexityHotspot{
+			{File: "complex.py", Line: 10, Symbol: "process_data", CyclomaticComplexity: 25, CognitiveComplexity: 12},
+		},
+	}
+
+	data, err := r.formatSARIF(result)
+	if err != nil {
+		t.Fatalf("formatSARIF returned error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("formatSARIF output is not valid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" {
+		t.Errorf("expected SARIF version 2.1.0, got %s", log.Version)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(log.Runs))
+	}
+
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "reducto" {
+		t.Errorf("expected driver name reducto, got %s", run.Tool.Driver.Name)
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 results (cyclomatic + cognitive), got %d", len(run.Results))
+	}
+
+	var gotError bool
+	for _, res := range run.Results {
+		if res.RuleID == "reducto/cyclomatic-complexity" && res.Level == "error" {
+			gotError = true
+		}
+	}
+	if !gotError {
+		t.Error("expected cyclomatic complexity 25 (>= 2x default threshold 10) to be level error")
+	}
+
+	if run.Invocations[0].Properties["sessionId"] != "sarif-session" {
+		t.Errorf("expected sessionId property sarif-session, got %s", run.Invocations[0].Properties["sessionId"])
+	}
+}
+
+func TestFormatSARIFReport(t *testing.T) {
+	cfg := &models.Config{OutputFormat: "sarif"}
+	r := New(func() *models.Config { return cfg })
+
+	report := &models.Report{
+		SessionID:   "report-session",
+		GeneratedAt: time.Now(),
+		MetricsDelta: models.MetricsDelta{
+			CyclomaticComplexityDelta: -2,
+			CognitiveComplexityDelta:  -3,
+		},
+	}
+	result := &models.RefactorResult{
+		SessionID: "report-session",
+		Changes: []models.FileChange{
+			{Path: "test.py", Description: "Simplified function", Original: "def old():\n    pass\n", Modified: "def new():\n    pass\n"},
+		},
+	}
+
+	data, err := r.formatSARIFReport(report, result)
+	if err != nil {
+		t.Fatalf("formatSARIFReport returned error: %v", err)
+	}
+
+	if !strings.Contains(string(data), "test.py") {
+		t.Error("expected SARIF output to reference the changed file")
+	}
+	if !strings.Contains(string(data), "def new()") {
+		t.Error("expected SARIF output to carry the replacement content")
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("formatSARIFReport output is not valid JSON: %v", err)
+	}
+	if len(log.Runs[0].Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(log.Runs[0].Results))
+	}
+	if len(log.Runs[0].Results[0].Fixes) != 1 {
+		t.Fatalf("expected 1 fix, got %d", len(log.Runs[0].Results[0].Fixes))
+	}
+}
@@ -0,0 +1,76 @@
+// Package rpc implements the Content-Length-framed JSON-RPC 2.0 wire
+// format used by both the LSP client and the MCP sidecar, so the two don't
+// each carry their own copy of the header-parsing/write-locking logic.
+package rpc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StreamCodec reads and writes Content-Length-framed messages over a pair
+// of streams, the way LSP and several JSON-RPC-over-stdio protocols do:
+// each message is preceded by a "Content-Length: N\r\n\r\n" header giving
+// the byte length of the body that follows.
+type StreamCodec struct {
+	r *bufio.Reader
+	w io.Writer
+
+	writeMu sync.Mutex
+}
+
+// NewStreamCodec wraps r and w for framed reads and writes. r and w are
+// typically a subprocess's stdout and stdin respectively.
+func NewStreamCodec(r io.Reader, w io.Writer) *StreamCodec {
+	return &StreamCodec{r: bufio.NewReader(r), w: w}
+}
+
+// ReadMessage blocks until a full framed message has arrived and returns
+// its body. It returns the underlying error (including io.EOF) once the
+// stream ends.
+func (c *StreamCodec) ReadMessage() ([]byte, error) {
+	var contentLength int
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			if contentLength <= 0 {
+				return nil, fmt.Errorf("rpc: message with no Content-Length header")
+			}
+			body := make([]byte, contentLength)
+			if _, err := io.ReadFull(c.r, body); err != nil {
+				return nil, err
+			}
+			return body, nil
+		}
+
+		if strings.HasPrefix(line, "Content-Length: ") {
+			contentLength, _ = strconv.Atoi(strings.TrimPrefix(line, "Content-Length: "))
+		}
+	}
+}
+
+// WriteMessage frames body with a Content-Length header and writes it.
+// Concurrent calls are serialized so frames from different goroutines
+// can't interleave on the wire.
+func (c *StreamCodec) WriteMessage(body []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	header := fmt.Sprintf("Content-Length: %d\r\n\r\n", len(body))
+	if _, err := c.w.Write([]byte(header)); err != nil {
+		return fmt.Errorf("rpc: failed to write header: %w", err)
+	}
+	if _, err := c.w.Write(body); err != nil {
+		return fmt.Errorf("rpc: failed to write body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStreamCodecRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	codec := NewStreamCodec(&buf, &buf)
+
+	if err := codec.WriteMessage([]byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}`)); err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+
+	body, err := codec.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage failed: %v", err)
+	}
+	if string(body) != `{"jsonrpc":"2.0","id":1,"method":"ping"}` {
+		t.Errorf("ReadMessage() = %s, want the original body", body)
+	}
+}
+
+func TestStreamCodecReadsMultipleMessages(t *testing.T) {
+	var buf bytes.Buffer
+	codec := NewStreamCodec(&buf, &buf)
+
+	codec.WriteMessage([]byte(`{"id":1}`))
+	codec.WriteMessage([]byte(`{"id":2}`))
+
+	first, err := codec.ReadMessage()
+	if err != nil {
+		t.Fatalf("first ReadMessage failed: %v", err)
+	}
+	second, err := codec.ReadMessage()
+	if err != nil {
+		t.Fatalf("second ReadMessage failed: %v", err)
+	}
+	if string(first) != `{"id":1}` || string(second) != `{"id":2}` {
+		t.Errorf("got %s, %s; want {\"id\":1}, {\"id\":2}", first, second)
+	}
+}
+
+func TestStreamCodecReadMessageReturnsErrorOnMissingHeader(t *testing.T) {
+	buf := bytes.NewBufferString("\r\n")
+	codec := NewStreamCodec(buf, &bytes.Buffer{})
+
+	if _, err := codec.ReadMessage(); err == nil {
+		t.Error("expected an error for a frame with no Content-Length header")
+	}
+}
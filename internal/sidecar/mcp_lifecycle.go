@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -18,6 +20,11 @@ import (
 	"github.com/alexkarsten/reducto/pkg/models"
 )
 
+// maxRestartAttempts bounds the self-restart MCPManager performs in reap
+// when the sidecar process exits unexpectedly while a call is pending,
+// after which it gives up and leaves the crash surfaced as a result error.
+const maxRestartAttempts = 5
+
 type AnalyzeResult struct {
 	TotalFiles   int                 `json:"total_files"`
 	TotalSymbols int                 `json:"total_symbols"`
@@ -40,6 +47,18 @@ type MCPManager struct {
 	cmd        *exec.Cmd
 	resultChan chan map[string]interface{}
 	mu         sync.Mutex
+
+	// command and path remember the arguments of the most recent Start, so
+	// Restart can relaunch with them after an unexpected exit.
+	command string
+	path    string
+
+	running      atomic.Bool
+	stopping     bool
+	done         chan struct{}
+	pendingCalls atomic.Int32
+
+	restartAttempts int
 }
 
 func NewMCPManager(rootDir string, cfg *models.Config) *MCPManager {
@@ -60,6 +79,12 @@ func (m *MCPManager) Start(command, path string) error {
 		return err
 	}
 
+	m.mu.Lock()
+	m.command = command
+	m.path = path
+	m.stopping = false
+	m.mu.Unlock()
+
 	args := []string{
 		"-m", "ai_sidecar.mcp_entry",
 		"--root", path,
@@ -121,6 +146,9 @@ func (m *MCPManager) Start(command, path string) error {
 	}
 
 	m.process = m.cmd.Process
+	m.done = make(chan struct{})
+	m.running.Store(true)
+	go m.reap()
 
 	m.server = mcp.NewServer(m.rootDir)
 	go func() {
@@ -131,6 +159,91 @@ func (m *MCPManager) Start(command, path string) error {
 	return nil
 }
 
+// reap waits for the sidecar process to exit and records the outcome, so
+// IsRunning reflects reality instead of a Signal(0) probe that can't tell
+// a live process apart from the zombie exec.Cmd leaves behind until
+// something calls Wait. An exit Stop didn't request is treated as a
+// crash: it's pushed onto resultChan as an error sentinel so a pending
+// WaitForResult returns immediately instead of waiting out its full
+// timeout, and, if a call is still pending, it triggers a bounded,
+// exponential-backoff Restart.
+func (m *MCPManager) reap() {
+	err := m.cmd.Wait()
+	m.running.Store(false)
+
+	m.mu.Lock()
+	stopping := m.stopping
+	done := m.done
+	m.mu.Unlock()
+	close(done)
+
+	if stopping {
+		return
+	}
+
+	select {
+	case m.resultChan <- map[string]interface{}{"error": fmt.Sprintf("sidecar exited unexpectedly: %v", err)}:
+	default:
+	}
+
+	if m.pendingCalls.Load() == 0 {
+		return
+	}
+
+	if restartErr := m.Restart(); restartErr != nil {
+		select {
+		case m.resultChan <- map[string]interface{}{"error": fmt.Sprintf("sidecar restart failed: %v", restartErr)}:
+		default:
+		}
+	}
+}
+
+// Restart relaunches the sidecar with the command and path from the most
+// recent Start, backing off exponentially between attempts the same way
+// Supervisor does, up to maxRestartAttempts before giving up.
+func (m *MCPManager) Restart() error {
+	m.mu.Lock()
+	command, path := m.command, m.path
+	attempt := m.restartAttempts
+	m.mu.Unlock()
+
+	if attempt >= maxRestartAttempts {
+		return fmt.Errorf("sidecar restart budget exhausted after %d attempts", attempt)
+	}
+
+	delay := time.Duration(float64(restartBackoffBase) * math.Pow(2, float64(attempt)))
+	if delay > restartBackoffMax {
+		delay = restartBackoffMax
+	}
+	time.Sleep(delay)
+
+	m.mu.Lock()
+	m.restartAttempts++
+	m.mu.Unlock()
+
+	return m.Start(command, path)
+}
+
+// Ping reports whether the sidecar process is alive, honoring ctx's
+// deadline/cancellation. A full MCP `ping` round trip would need the
+// python sidecar to answer a request on its own channel, but in this
+// wiring the sidecar is the MCP client and Go's mcp.Server is the server,
+// so there's no request path running the other way; this exposes the
+// liveness state reap already tracks, bounded by ctx, which is what
+// Supervisor's health loop needs.
+func (m *MCPManager) Ping(ctx context.Context) error {
+	if !m.IsRunning() {
+		return fmt.Errorf("sidecar process is not running")
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return nil
+	}
+}
+
 func (m *MCPManager) readResultFromStderr(reader io.Reader) {
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
@@ -153,20 +266,41 @@ func (m *MCPManager) readResultFromStderr(reader io.Reader) {
 }
 
 func (m *MCPManager) Stop() {
-	if m.process != nil {
-		if runtime.GOOS == "windows" {
-			m.process.Kill()
-		} else {
-			syscall.Kill(-m.process.Pid, syscall.SIGTERM)
-		}
-		if m.cmd != nil {
-			m.cmd.Wait()
-		}
-		m.process = nil
+	m.mu.Lock()
+	m.stopping = true
+	process := m.process
+	done := m.done
+	m.process = nil
+	m.mu.Unlock()
+
+	if process == nil {
+		return
 	}
+
+	if runtime.GOOS == "windows" {
+		process.Kill()
+	} else {
+		syscall.Kill(-process.Pid, syscall.SIGTERM)
+	}
+
+	// reap does the actual cmd.Wait(); block here until it has, so Stop
+	// doesn't return before the process is gone.
+	if done != nil {
+		<-done
+	}
+
+	m.mu.Lock()
+	m.restartAttempts = 0
+	m.mu.Unlock()
 }
 
+// WaitForResult blocks for a result from the sidecar's stderr RESULT:
+// protocol, or for reap's error sentinel if the process exits
+// unexpectedly first, whichever comes first.
 func (m *MCPManager) WaitForResult(timeout time.Duration) (map[string]interface{}, error) {
+	m.pendingCalls.Add(1)
+	defer m.pendingCalls.Add(-1)
+
 	select {
 	case result := <-m.resultChan:
 		return result, nil
@@ -340,14 +474,9 @@ func (m *MCPManager) checkPythonInstalled() error {
 	return nil
 }
 
+// IsRunning reports the supervised state reap maintains, rather than
+// probing the OS with Signal(0) on every call, which can't tell a live
+// process apart from a zombie exec.Cmd nobody has reaped yet.
 func (m *MCPManager) IsRunning() bool {
-	if m.process == nil {
-		return false
-	}
-
-	if runtime.GOOS == "windows" {
-		return m.process.Signal(syscall.Signal(0)) == nil
-	}
-
-	return m.process.Signal(syscall.Signal(0)) == nil
+	return m.running.Load()
 }
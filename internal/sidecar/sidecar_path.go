@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -32,42 +33,91 @@ func getOrCreateSidecarPath() (string, error) {
 	return extractedPath, extractErr
 }
 
+// currentSymlinkName is the name of the symlink that points at the sidecar
+// version currently in use, so an in-progress upgrade extraction never
+// clobbers files a running instance has open.
+const currentSymlinkName = "current"
+
+// extractEmbeddedSidecar lays the embedded sidecar out at
+// <dataDir>/sidecar/<version>/ and atomically repoints <dataDir>/sidecar/current
+// at it, leaving any previously installed version on disk untouched so a
+// failed upgrade can roll back to it.
 func extractEmbeddedSidecar() (string, error) {
 	dataDir, err := getDataDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get data directory: %w", err)
 	}
 
-	sidecarDir := filepath.Join(dataDir, "sidecar")
-	versionFile := filepath.Join(sidecarDir, ".version")
+	sidecarRoot := filepath.Join(dataDir, "sidecar")
+	versionDir := filepath.Join(sidecarRoot, sidecarVersion)
+	currentLink := filepath.Join(sidecarRoot, currentSymlinkName)
 
-	if storedVersion, err := os.ReadFile(versionFile); err == nil {
-		if string(storedVersion) == sidecarVersion {
-			if validateSidecarDir(sidecarDir) {
-				return sidecarDir, nil
-			}
+	if validateSidecarDir(versionDir) {
+		if err := repointCurrent(sidecarRoot, currentLink, sidecarVersion); err != nil {
+			return "", err
 		}
+		return versionDir, nil
 	}
 
-	if err := os.RemoveAll(sidecarDir); err != nil && !os.IsNotExist(err) {
-		return "", fmt.Errorf("failed to remove old sidecar: %w", err)
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create sidecar version directory: %w", err)
 	}
 
-	if err := os.MkdirAll(sidecarDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create sidecar directory: %w", err)
+	if err := copyFS(embeddedFS, versionDir); err != nil {
+		os.RemoveAll(versionDir)
+		return "", fmt.Errorf("failed to extract sidecar: %w", err)
 	}
 
-	if err := copyFS(embeddedFS, sidecarDir); err != nil {
-		return "", fmt.Errorf("failed to extract sidecar: %w", err)
+	if err := repointCurrent(sidecarRoot, currentLink, sidecarVersion); err != nil {
+		return "", err
+	}
+
+	return versionDir, nil
+}
+
+// repointCurrent atomically repoints the "current" symlink at version by
+// creating a new symlink under a temp name and renaming it over the old
+// one, so a crash mid-upgrade leaves either the old or the new link intact
+// but never a half-written one.
+func repointCurrent(sidecarRoot, currentLink, version string) error {
+	tmpLink := currentLink + ".tmp"
+	os.Remove(tmpLink)
+
+	if err := os.Symlink(version, tmpLink); err != nil {
+		return fmt.Errorf("failed to create sidecar version symlink: %w", err)
 	}
 
-	if err := os.WriteFile(versionFile, []byte(sidecarVersion), 0644); err != nil {
-		return "", fmt.Errorf("failed to write version file: %w", err)
+	if err := os.Rename(tmpLink, currentLink); err != nil {
+		os.Remove(tmpLink)
+		return fmt.Errorf("failed to activate sidecar version %s: %w", version, err)
 	}
 
-	return sidecarDir, nil
+	return nil
 }
 
+// RollbackSidecar repoints "current" back at a previously extracted
+// version. Supervisor does not call it: a sidecar that fails a health
+// check is restarted at the same version.
+func RollbackSidecar(version string) error {
+	dataDir, err := getDataDir()
+	if err != nil {
+		return err
+	}
+	sidecarRoot := filepath.Join(dataDir, "sidecar")
+	versionDir := filepath.Join(sidecarRoot, version)
+	if !validateSidecarDir(versionDir) {
+		return fmt.Errorf("sidecar version %s is not installed", version)
+	}
+	return repointCurrent(sidecarRoot, filepath.Join(sidecarRoot, currentSymlinkName), version)
+}
+
+// pipFallbackEnvVar opts a user into managing the Python sidecar dependency
+// themselves via `pip install --user ai-sidecar==<version>` when
+// findLocalSidecar finds no local copy. findLocalSidecar runs only when no
+// embedded sidecar has been set, so the variable has no effect in a build
+// that embeds one.
+const pipFallbackEnvVar = "REDUCTO_SIDECAR_PIP_FALLBACK"
+
 func findLocalSidecar() (string, error) {
 	candidates := []string{
 		"python",
@@ -95,9 +145,51 @@ func findLocalSidecar() (string, error) {
 		}
 	}
 
+	if os.Getenv(pipFallbackEnvVar) != "" {
+		return installSidecarViaPip(sidecarVersion)
+	}
+
 	return "", fmt.Errorf("could not find ai_sidecar module; ensure Python sidecar is installed")
 }
 
+// installSidecarViaPip installs the sidecar into the user's site-packages
+// via `pip install --user ai-sidecar==<version>` for users who prefer to
+// manage the Python dependency outside of reducto's embedded copy. The
+// returned path is empty because the module is importable from the
+// standard site-packages location rather than a directory reducto extracted
+// itself; callers invoke it with `python3 -m ai_sidecar.mcp_entry` as usual.
+func installSidecarViaPip(version string) (string, error) {
+	pipCmd := exec.Command("python3", "-m", "pip", "install", "--user",
+		fmt.Sprintf("ai-sidecar==%s", version))
+	pipCmd.Stdout = os.Stderr
+	pipCmd.Stderr = os.Stderr
+	if err := pipCmd.Run(); err != nil {
+		return "", fmt.Errorf("pip install --user ai-sidecar==%s failed: %w", version, err)
+	}
+	return "", nil
+}
+
+// DiagnosticInfo summarizes the sidecar's resolved location and version for
+// use by `reducto support dump`.
+type DiagnosticInfo struct {
+	Path       string
+	Version    string
+	Resolved   bool
+	ResolveErr string
+}
+
+// Diagnose resolves the sidecar path without mutating any running state,
+// reporting the error rather than returning it so a broken sidecar install
+// doesn't prevent a support dump from including everything else.
+func Diagnose() DiagnosticInfo {
+	path, err := getOrCreateSidecarPath()
+	info := DiagnosticInfo{Path: path, Version: sidecarVersion, Resolved: err == nil}
+	if err != nil {
+		info.ResolveErr = err.Error()
+	}
+	return info
+}
+
 func validateSidecarDir(path string) bool {
 	initPath := filepath.Join(path, "ai_sidecar", "__init__.py")
 	if _, err := os.Stat(initPath); err == nil {
@@ -0,0 +1,251 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// State is a Supervisor lifecycle state.
+type State string
+
+const (
+	StateStopped    State = "stopped"
+	StateStarting   State = "starting"
+	StateRunning    State = "running"
+	StateUnhealthy  State = "unhealthy"
+	StateRestarting State = "restarting"
+	StateFailed     State = "failed"
+)
+
+// Event reports a Supervisor state transition.
+type Event struct {
+	State State
+	Err   error
+	Time  time.Time
+}
+
+// backoff bounds for restart attempts, doubling from base up to max.
+const (
+	restartBackoffBase = 500 * time.Millisecond
+	restartBackoffMax  = 30 * time.Second
+	healthTimeout      = 5 * time.Second
+	healthInterval     = 10 * time.Second
+)
+
+// Supervisor keeps a long-running MCP sidecar process alive: it starts the
+// process via an MCPManager, periodically checks its health, and restarts
+// it with exponential backoff on crash or unresponsiveness. State changes
+// are published on Events() so callers (e.g. the CLI's --watch mode) can
+// surface them without polling.
+type Supervisor struct {
+	rootDir string
+	cfg     *models.Config
+
+	mu       sync.Mutex
+	mgr      *MCPManager
+	state    State
+	attempts int
+	events   chan Event
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// NewSupervisor builds a Supervisor for the sidecar rooted at rootDir.
+func NewSupervisor(rootDir string, cfg *models.Config) *Supervisor {
+	return &Supervisor{
+		rootDir: rootDir,
+		cfg:     cfg,
+		state:   StateStopped,
+		events:  make(chan Event, 16),
+	}
+}
+
+// Events returns a channel of state transitions. It is never closed by
+// Supervisor; callers should stop reading once Stop has returned.
+func (s *Supervisor) Events() <-chan Event {
+	return s.events
+}
+
+// Start launches the sidecar and begins the health-check/restart loop.
+func (s *Supervisor) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.state == StateRunning || s.state == StateStarting {
+		s.mu.Unlock()
+		return fmt.Errorf("supervisor already started")
+	}
+	runCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	s.attempts = 0
+	s.mu.Unlock()
+
+	if err := s.spawn(); err != nil {
+		s.setState(StateFailed, err)
+		return err
+	}
+
+	go s.superviseLoop(runCtx)
+	return nil
+}
+
+// Stop terminates the sidecar and the supervise loop, waiting for cleanup
+// to finish or ctx to expire, whichever comes first.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	done := s.done
+	mgr := s.mgr
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	if mgr != nil {
+		mgr.Stop()
+	}
+
+	if done == nil {
+		s.setState(StateStopped, nil)
+		return nil
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.setState(StateStopped, nil)
+	return nil
+}
+
+// Restart forces an immediate restart, resetting the backoff counter.
+func (s *Supervisor) Restart() error {
+	s.mu.Lock()
+	mgr := s.mgr
+	s.attempts = 0
+	s.mu.Unlock()
+
+	if mgr != nil {
+		mgr.Stop()
+	}
+	s.setState(StateRestarting, nil)
+	if err := s.spawn(); err != nil {
+		s.setState(StateFailed, err)
+		return err
+	}
+	return nil
+}
+
+// Health pings the sidecar and reports whether it answered within
+// healthTimeout. A process that has exited is always unhealthy.
+func (s *Supervisor) Health() error {
+	s.mu.Lock()
+	mgr := s.mgr
+	s.mu.Unlock()
+
+	if mgr == nil {
+		return fmt.Errorf("sidecar process is not running")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthTimeout)
+	defer cancel()
+	return mgr.Ping(ctx)
+}
+
+func (s *Supervisor) spawn() error {
+	mgr := NewMCPManager(s.rootDir, s.cfg)
+	if err := mgr.Start("serve", s.rootDir); err != nil {
+		return fmt.Errorf("failed to start sidecar: %w", err)
+	}
+
+	s.mu.Lock()
+	s.mgr = mgr
+	s.mu.Unlock()
+
+	s.setState(StateRunning, nil)
+	return nil
+}
+
+func (s *Supervisor) superviseLoop(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Health(); err != nil {
+				s.setState(StateUnhealthy, err)
+				if err := s.restartWithBackoff(ctx); err != nil {
+					s.setState(StateFailed, err)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Supervisor) restartWithBackoff(ctx context.Context) error {
+	s.mu.Lock()
+	s.attempts++
+	attempt := s.attempts
+	s.mu.Unlock()
+
+	delay := time.Duration(float64(restartBackoffBase) * math.Pow(2, float64(attempt-1)))
+	if delay > restartBackoffMax {
+		delay = restartBackoffMax
+	}
+
+	s.setState(StateRestarting, nil)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+	}
+
+	s.mu.Lock()
+	mgr := s.mgr
+	s.mu.Unlock()
+	if mgr != nil {
+		mgr.Stop()
+	}
+
+	return s.spawn()
+}
+
+func (s *Supervisor) setState(state State, err error) {
+	s.mu.Lock()
+	s.state = state
+	s.mu.Unlock()
+
+	select {
+	case s.events <- Event{State: state, Err: err, Time: time.Now()}:
+	default:
+		// Slow consumer: drop the oldest rather than block the supervise loop.
+		select {
+		case <-s.events:
+		default:
+		}
+		select {
+		case s.events <- Event{State: state, Err: err, Time: time.Now()}:
+		default:
+		}
+	}
+}
+
+// CurrentState reports the Supervisor's last known state.
+func (s *Supervisor) CurrentState() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
@@ -0,0 +1,301 @@
+// Package treesitter wraps go-tree-sitter with the grammars reducto needs
+// (Go/Python/TypeScript/JavaScript) behind a single Parse entry point, so
+// callers get a real AST instead of the regex/line-scanning approximations
+// internal/parser and mcp.Server previously relied on.
+package treesitter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// cacheKey identifies a parsed tree by its source path and content hash, so
+// re-parsing an unchanged file (the common case across repeated get_symbols/
+// get_ast/get_complexity calls in one session) is a map lookup.
+type cacheKey struct {
+	path string
+	hash string
+}
+
+// Parser parses source into Tree-sitter ASTs and memoizes the result per
+// (path, content hash). It is safe for concurrent use.
+type Parser struct {
+	mu    sync.Mutex
+	trees map[cacheKey]*sitter.Tree
+}
+
+// New returns a ready-to-use Parser with an empty cache.
+func New() *Parser {
+	return &Parser{trees: make(map[cacheKey]*sitter.Tree)}
+}
+
+func languageFor(lang models.Language) (*sitter.Language, error) {
+	switch lang {
+	case models.LanguageGo:
+		return golang.GetLanguage(), nil
+	case models.LanguagePython:
+		return python.GetLanguage(), nil
+	case models.LanguageTypeScript:
+		return typescript.GetLanguage(), nil
+	case models.LanguageJavaScript:
+		return javascript.GetLanguage(), nil
+	default:
+		return nil, fmt.Errorf("treesitter: unsupported language: %s", lang)
+	}
+}
+
+// Parse returns the Tree-sitter AST for content, keyed in the cache by path
+// and sha256(content) so an unchanged file is never re-parsed.
+func (p *Parser) Parse(ctx context.Context, lang models.Language, path, content string) (*sitter.Tree, error) {
+	sum := sha256.Sum256([]byte(content))
+	key := cacheKey{path: path, hash: hex.EncodeToString(sum[:])}
+
+	p.mu.Lock()
+	if tree, ok := p.trees[key]; ok {
+		p.mu.Unlock()
+		return tree, nil
+	}
+	p.mu.Unlock()
+
+	language, err := languageFor(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	sitterParser := sitter.NewParser()
+	sitterParser.SetLanguage(language)
+
+	tree, err := sitterParser.ParseCtx(ctx, nil, []byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("treesitter: failed to parse %s: %w", path, err)
+	}
+
+	p.mu.Lock()
+	p.trees[key] = tree
+	p.mu.Unlock()
+
+	return tree, nil
+}
+
+// symbolQueries maps each supported language to the Tree-sitter query used
+// to locate symbol declarations. @name must capture the declared
+// identifier; the pattern's outer capture (@function, @class, @struct or
+// @interface) marks the declaration node and, via symbolKindForCapture,
+// sets the Symbol.Type recorded for it. A match lacking either is skipped.
+var symbolQueries = map[models.Language]string{
+	models.LanguageGo: `
+		(function_declaration name: (identifier) @name) @function
+		(method_declaration name: (field_identifier) @name) @function
+		(type_spec name: (type_identifier) @name type: (struct_type)) @struct
+		(type_spec name: (type_identifier) @name type: (interface_type)) @interface
+	`,
+	models.LanguagePython: `
+		(function_definition name: (identifier) @name) @function
+		(class_definition name: (identifier) @name) @class
+	`,
+	models.LanguageTypeScript: `
+		(function_declaration name: (identifier) @name) @function
+		(method_definition name: (property_identifier) @name) @function
+		(class_declaration name: (type_identifier) @name) @class
+		(interface_declaration name: (type_identifier) @name) @interface
+	`,
+	models.LanguageJavaScript: `
+		(function_declaration name: (identifier) @name) @function
+		(method_definition name: (property_identifier) @name) @function
+		(class_declaration name: (identifier) @name) @class
+	`,
+}
+
+// symbolKindForCapture maps a query's outer capture name to the Symbol.Type
+// recorded for matches of that capture.
+var symbolKindForCapture = map[string]string{
+	"function":  "function",
+	"class":     "class",
+	"struct":    "struct",
+	"interface": "interface",
+}
+
+// ExtractSymbols runs lang's symbol query against tree and returns one
+// models.Symbol per match, with StartLine/EndLine taken from the matched
+// node's byte range rather than from scanning for a matching brace/indent.
+func ExtractSymbols(tree *sitter.Tree, source []byte, lang models.Language, path string) ([]models.Symbol, error) {
+	queryStr, ok := symbolQueries[lang]
+	if !ok {
+		return nil, fmt.Errorf("treesitter: no symbol query for language: %s", lang)
+	}
+
+	language, err := languageFor(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := sitter.NewQuery([]byte(queryStr), language)
+	if err != nil {
+		return nil, fmt.Errorf("treesitter: invalid symbol query for %s: %w", lang, err)
+	}
+	defer query.Close()
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(query, tree.RootNode())
+
+	var symbols []models.Symbol
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+
+		var name string
+		var declNode *sitter.Node
+		symbolType := "function"
+
+		for _, capture := range match.Captures {
+			captureName := query.CaptureNameForId(capture.Index)
+			if captureName == "name" {
+				name = capture.Node.Content(source)
+				continue
+			}
+			if kind, ok := symbolKindForCapture[captureName]; ok {
+				symbolType = kind
+				declNode = capture.Node
+			}
+		}
+
+		if name == "" || declNode == nil {
+			continue
+		}
+
+		symbols = append(symbols, models.Symbol{
+			Name:      name,
+			Type:      symbolType,
+			File:      path,
+			StartLine: int(declNode.StartPoint().Row) + 1,
+			EndLine:   int(declNode.EndPoint().Row) + 1,
+		})
+	}
+
+	return symbols, nil
+}
+
+// ASTNode is a compact, JSON-friendly projection of a *sitter.Node: type,
+// source span, and named children only (anonymous tokens like punctuation
+// are omitted to keep the tree readable).
+type ASTNode struct {
+	Type       string    `json:"type"`
+	FieldName  string    `json:"field_name,omitempty"`
+	StartPoint [2]int    `json:"start_point"`
+	EndPoint   [2]int    `json:"end_point"`
+	Children   []ASTNode `json:"children,omitempty"`
+}
+
+// ToJSON walks node up to maxDepth levels deep (0 = root only, negative =
+// unlimited) and returns the compact tree used by mcp's get_ast tool.
+func ToJSON(node *sitter.Node, source []byte, parent *sitter.Node, maxDepth int) ASTNode {
+	out := ASTNode{
+		Type:       node.Type(),
+		StartPoint: [2]int{int(node.StartPoint().Row), int(node.StartPoint().Column)},
+		EndPoint:   [2]int{int(node.EndPoint().Row), int(node.EndPoint().Column)},
+	}
+
+	if parent != nil {
+		for i := 0; i < int(parent.NamedChildCount()); i++ {
+			if parent.NamedChild(i) == node {
+				out.FieldName = parent.FieldNameForChild(i)
+				break
+			}
+		}
+	}
+
+	if maxDepth == 0 {
+		return out
+	}
+
+	childDepth := maxDepth - 1
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		out.Children = append(out.Children, ToJSON(child, source, node, childDepth))
+	}
+
+	return out
+}
+
+// complexityNodeTypes lists the grammar node types, across all supported
+// languages, that each contribute one branch to cyclomatic complexity (a
+// binary_expression only when its operator is && or ||) —
+// counting AST nodes instead of matching keyword prefixes means decorators,
+// multi-line conditions, and ternaries are all counted correctly.
+var complexityNodeTypes = map[string]bool{
+	"if_statement":           true,
+	"elif_clause":            true,
+	"else_clause":            true,
+	"for_statement":          true,
+	"while_statement":        true,
+	"case_clause":            true,
+	"switch_case":            true,
+	"conditional_expression": true,
+	"binary_expression":      true,
+	"boolean_operator":       true,
+}
+
+// CalculateComplexity walks tree counting branch-introducing node types,
+// replacing the line-prefix heuristics parser.CalculateComplexity used.
+func CalculateComplexity(tree *sitter.Tree, source []byte) models.ComplexityMetrics {
+	metrics := models.ComplexityMetrics{
+		LinesOfCode: len(splitLines(source)),
+	}
+
+	var nesting int
+	var walk func(node *sitter.Node, depth int)
+	walk = func(node *sitter.Node, depth int) {
+		counts := complexityNodeTypes[node.Type()]
+		if counts && node.Type() == "binary_expression" {
+			op := node.ChildByFieldName("operator")
+			counts = op != nil && (op.Type() == "&&" || op.Type() == "||")
+		}
+		if counts {
+			metrics.CyclomaticComplexity++
+		}
+
+		branchesNesting := node.Type() == "if_statement" || node.Type() == "for_statement" || node.Type() == "while_statement"
+		if branchesNesting {
+			metrics.CognitiveComplexity += 1 + nesting
+			nesting++
+		}
+
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			walk(node.NamedChild(i), depth+1)
+		}
+
+		if branchesNesting {
+			nesting--
+		}
+	}
+	walk(tree.RootNode(), 0)
+
+	return metrics
+}
+
+func splitLines(source []byte) []string {
+	var lines []string
+	start := 0
+	for i, b := range source {
+		if b == '\n' {
+			lines = append(lines, string(source[start:i]))
+			start = i + 1
+		}
+	}
+	lines = append(lines, string(source[start:]))
+	return lines
+}
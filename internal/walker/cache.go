@@ -0,0 +1,202 @@
+package walker
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMaxCacheBytes is the default total-content byte cap for NewLRUCache.
+const DefaultMaxCacheBytes = 256 * 1024 * 1024
+
+// CacheStats reports how effective a Cache has been at avoiding re-reads.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// Cache is consulted by Walker.Walk before os.ReadFile, keyed by a file's
+// path plus the stat fields (mtime, size) that change whenever its content
+// does. A cache hit lets Walk reuse the stored hash and content without
+// touching disk.
+type Cache interface {
+	// Get returns the cached hash and content for path if an entry exists
+	// whose mtime and size still match.
+	Get(path string, mtime time.Time, size int64) (hash, content string, ok bool)
+	// Put stores hash and content for path under the given mtime and size.
+	Put(path string, mtime time.Time, size int64, hash, content string)
+	Stats() CacheStats
+}
+
+// cacheKey identifies a cached read: a file's content can only still match
+// the cache if its path, mtime, and size are all unchanged.
+type cacheKey struct {
+	path  string
+	mtime int64
+	size  int64
+}
+
+// LRUCache is an in-memory Cache that evicts by total content bytes rather
+// than entry count, so a handful of huge files can't starve out everything
+// else the way a fixed entry-count cap would.
+type LRUCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	curBytes int64
+	order    *list.List
+	items    map[cacheKey]*list.Element
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type lruEntry struct {
+	key     cacheKey
+	hash    string
+	content string
+}
+
+// NewLRUCache returns an empty LRUCache capped at maxBytes of cached
+// content. maxBytes <= 0 falls back to DefaultMaxCacheBytes.
+func NewLRUCache(maxBytes int64) *LRUCache {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxCacheBytes
+	}
+	return &LRUCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		items:    make(map[cacheKey]*list.Element),
+	}
+}
+
+func (c *LRUCache) Get(path string, mtime time.Time, size int64) (string, string, bool) {
+	key := cacheKey{path: path, mtime: mtime.UnixNano(), size: size}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		return "", "", false
+	}
+	c.order.MoveToFront(el)
+	c.hits.Add(1)
+
+	e := el.Value.(*lruEntry)
+	return e.hash, e.content, true
+}
+
+func (c *LRUCache) Put(path string, mtime time.Time, size int64, hash, content string) {
+	key := cacheKey{path: path, mtime: mtime.UnixNano(), size: size}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		old := el.Value.(*lruEntry)
+		c.curBytes += int64(len(content)) - int64(len(old.content))
+		el.Value = &lruEntry{key: key, hash: hash, content: content}
+		c.order.MoveToFront(el)
+	} else {
+		el := c.order.PushFront(&lruEntry{key: key, hash: hash, content: content})
+		c.items[key] = el
+		c.curBytes += int64(len(content))
+	}
+
+	c.evictLocked()
+}
+
+func (c *LRUCache) evictLocked() {
+	for c.curBytes > c.maxBytes {
+		back := c.order.Back()
+		if back == nil {
+			return
+		}
+		c.order.Remove(back)
+		e := back.Value.(*lruEntry)
+		delete(c.items, e.key)
+		c.curBytes -= int64(len(e.content))
+	}
+}
+
+func (c *LRUCache) Stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// DiskCache is an on-disk Cache that stores content keyed by its SHA-256
+// under dir, so identical content reached via different paths (e.g. the
+// same file on two branches) shares one copy on disk. The (path, mtime,
+// size) -> hash lookup itself only lives in memory for the process
+// lifetime; the blob store is what persists across runs.
+type DiskCache struct {
+	mu    sync.Mutex
+	dir   string
+	index map[cacheKey]string
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewDiskCache returns a DiskCache rooted at dir. If dir is empty, it
+// defaults to $XDG_CACHE_HOME/reducto (via os.UserCacheDir).
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if dir == "" {
+		cacheHome, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve cache directory: %w", err)
+		}
+		dir = filepath.Join(cacheHome, "reducto")
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "objects"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir, index: make(map[cacheKey]string)}, nil
+}
+
+func (c *DiskCache) blobPath(hash string) string {
+	return filepath.Join(c.dir, "objects", hash[:2], hash)
+}
+
+func (c *DiskCache) Get(path string, mtime time.Time, size int64) (string, string, bool) {
+	key := cacheKey{path: path, mtime: mtime.UnixNano(), size: size}
+
+	c.mu.Lock()
+	hash, ok := c.index[key]
+	c.mu.Unlock()
+	if !ok {
+		c.misses.Add(1)
+		return "", "", false
+	}
+
+	content, err := os.ReadFile(c.blobPath(hash))
+	if err != nil {
+		c.misses.Add(1)
+		return "", "", false
+	}
+
+	c.hits.Add(1)
+	return hash, string(content), true
+}
+
+func (c *DiskCache) Put(path string, mtime time.Time, size int64, hash, content string) {
+	blobPath := c.blobPath(hash)
+	if _, err := os.Stat(blobPath); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(blobPath), 0755); err == nil {
+			os.WriteFile(blobPath, []byte(content), 0644)
+		}
+	}
+
+	key := cacheKey{path: path, mtime: mtime.UnixNano(), size: size}
+	c.mu.Lock()
+	c.index[key] = hash
+	c.mu.Unlock()
+}
+
+func (c *DiskCache) Stats() CacheStats {
+	return CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
@@ -0,0 +1,107 @@
+package walker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLRUCacheHitAndMiss(t *testing.T) {
+	c := NewLRUCache(0)
+	mtime := time.Now()
+
+	if _, _, ok := c.Get("a.py", mtime, 4); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+
+	c.Put("a.py", mtime, 4, "deadbeef", "pass")
+
+	hash, content, ok := c.Get("a.py", mtime, 4)
+	if !ok || hash != "deadbeef" || content != "pass" {
+		t.Errorf("Get() = %q, %q, %v; want deadbeef, pass, true", hash, content, ok)
+	}
+
+	if _, _, ok := c.Get("a.py", mtime.Add(time.Second), 4); ok {
+		t.Error("expected a miss when mtime no longer matches")
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 {
+		t.Errorf("Stats() = %+v; want Hits=1 Misses=2", stats)
+	}
+}
+
+func TestLRUCacheEvictsByBytes(t *testing.T) {
+	c := NewLRUCache(10)
+	mtime := time.Now()
+
+	c.Put("a.py", mtime, 5, "hash-a", "aaaaa")
+	c.Put("b.py", mtime, 5, "hash-b", "bbbbb")
+	// Pushes total content past the 10-byte cap, evicting the
+	// least-recently-used entry (a.py).
+	c.Put("c.py", mtime, 5, "hash-c", "ccccc")
+
+	if _, _, ok := c.Get("a.py", mtime, 5); ok {
+		t.Error("expected a.py to have been evicted")
+	}
+	if _, _, ok := c.Get("b.py", mtime, 5); !ok {
+		t.Error("expected b.py to still be cached")
+	}
+	if _, _, ok := c.Get("c.py", mtime, 5); !ok {
+		t.Error("expected c.py to still be cached")
+	}
+}
+
+func TestDiskCacheSharesContentBySHA(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+	mtime := time.Now()
+
+	c.Put("branch-a/shared.py", mtime, 4, "samehash", "pass")
+	c.Put("branch-b/shared.py", mtime, 4, "samehash", "pass")
+
+	blobs, err := filepath.Glob(filepath.Join(dir, "objects", "*", "*"))
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	if len(blobs) != 1 {
+		t.Errorf("expected identical content to share one blob, got %d: %v", len(blobs), blobs)
+	}
+
+	hash, content, ok := c.Get("branch-b/shared.py", mtime, 4)
+	if !ok || hash != "samehash" || content != "pass" {
+		t.Errorf("Get() = %q, %q, %v; want samehash, pass, true", hash, content, ok)
+	}
+}
+
+func TestWalkUsesCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "a.py"), []byte("print('a')"), 0644)
+
+	cache := NewLRUCache(0)
+	w := New(nil, []string{"*.py"}, WithCache(cache))
+
+	if _, err := w.Walk(tmpDir); err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	if _, err := w.Walk(tmpDir); err != nil {
+		t.Fatalf("second Walk() error = %v", err)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("cache.Stats() = %+v; want 1 hit and 1 miss across two walks", stats)
+	}
+
+	projectStats, err := w.GetProjectStats(tmpDir)
+	if err != nil {
+		t.Fatalf("GetProjectStats() error = %v", err)
+	}
+	if projectStats.BytesRead == 0 {
+		t.Error("expected BytesRead to reflect the initial disk read")
+	}
+}
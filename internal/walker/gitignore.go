@@ -0,0 +1,307 @@
+package walker
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// IgnoreSource selects which families of ignore files the gitignore engine
+// should consult while walking. Sources are combined with bitwise OR.
+type IgnoreSource int
+
+const (
+	IgnoreSourceGitignore IgnoreSource = 1 << iota
+	IgnoreSourceDockerignore
+	IgnoreSourceReductoignore
+	IgnoreSourceGlobal
+
+	IgnoreSourceAll = IgnoreSourceGitignore | IgnoreSourceDockerignore | IgnoreSourceReductoignore | IgnoreSourceGlobal
+)
+
+// filenames returns the ignore filenames that should be read from a
+// directory for the sources selected on src.
+func (src IgnoreSource) filenames() []string {
+	var names []string
+	if src&IgnoreSourceGitignore != 0 {
+		names = append(names, ".gitignore")
+	}
+	if src&IgnoreSourceDockerignore != 0 {
+		names = append(names, ".dockerignore")
+	}
+	if src&IgnoreSourceReductoignore != 0 {
+		names = append(names, ".reductoignore")
+	}
+	return names
+}
+
+type ignorePattern struct {
+	raw      string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+	regex    *regexp.Regexp
+}
+
+func (p *ignorePattern) matches(relPath string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	return p.regex.MatchString(relPath)
+}
+
+// compileIgnoreLine parses a single gitignore-format line. It returns
+// ok=false for blank lines and comments.
+func compileIgnoreLine(line string) (*ignorePattern, bool) {
+	line = strings.TrimRight(line, " \t")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, false
+	}
+
+	p := &ignorePattern{raw: line}
+
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+
+	// A leading "\" escapes a literal "!" or "#".
+	line = strings.TrimPrefix(line, "\\")
+
+	if strings.HasSuffix(line, "/") && len(line) > 1 {
+		p.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+
+	if strings.HasPrefix(line, "/") {
+		p.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		// Any pattern with a non-trailing slash is anchored to the
+		// directory that owns it, per gitignore semantics.
+		p.anchored = true
+	}
+
+	p.regex = globToRegexp(line, p.anchored)
+	return p, true
+}
+
+// globToRegexp translates a gitignore glob (after anchor/dir-only markers
+// have been stripped) into an anchored regular expression matched against
+// a slash-separated path relative to the directory owning the pattern.
+func globToRegexp(pattern string, anchored bool) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		switch runes[i] {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				switch {
+				case i+2 < len(runes) && runes[i+2] == '/':
+					sb.WriteString("(?:.*/)?")
+					i += 3
+				case i == 0:
+					sb.WriteString(".*")
+					i += 2
+				default:
+					sb.WriteString(".*")
+					i += 2
+				}
+				continue
+			}
+			sb.WriteString("[^/]*")
+			i++
+		case '?':
+			sb.WriteString("[^/]")
+			i++
+		case '[':
+			j := i + 1
+			cls := strings.Builder{}
+			cls.WriteString("[")
+			if j < len(runes) && runes[j] == '!' {
+				cls.WriteString("^")
+				j++
+			}
+			for j < len(runes) && runes[j] != ']' {
+				cls.WriteRune(runes[j])
+				j++
+			}
+			cls.WriteString("]")
+			sb.WriteString(cls.String())
+			i = j + 1
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(runes[i])))
+			i++
+		}
+	}
+
+	sb.WriteString("(?:/.*)?$")
+	return regexp.MustCompile(sb.String())
+}
+
+// gitignoreEngine evaluates ignore rules gathered from per-directory scopes,
+// applying last-match-wins semantics the same way Git does.
+type gitignoreEngine struct {
+	root    string
+	sources IgnoreSource
+
+	mu          sync.Mutex
+	scopeCache  map[string][]*ignorePattern
+	global      []*ignorePattern
+	globalOnce  sync.Once
+	userIgnores []string
+}
+
+func newGitignoreEngine(root string, sources IgnoreSource, userIgnorePatterns []string) *gitignoreEngine {
+	return &gitignoreEngine{
+		root:        root,
+		sources:     sources,
+		scopeCache:  make(map[string][]*ignorePattern),
+		userIgnores: userIgnorePatterns,
+	}
+}
+
+func (e *gitignoreEngine) loadFile(path string) []*ignorePattern {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var patterns []*ignorePattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if p, ok := compileIgnoreLine(scanner.Text()); ok {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+// loadDir returns the ignore patterns declared directly inside dir,
+// caching the result for subsequent lookups during the same walk.
+func (e *gitignoreEngine) loadDir(dir string) []*ignorePattern {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if patterns, ok := e.scopeCache[dir]; ok {
+		return patterns
+	}
+
+	var patterns []*ignorePattern
+	for _, name := range e.sources.filenames() {
+		patterns = append(patterns, e.loadFile(filepath.Join(dir, name))...)
+	}
+
+	e.scopeCache[dir] = patterns
+	return patterns
+}
+
+func (e *gitignoreEngine) globalPatterns() []*ignorePattern {
+	e.globalOnce.Do(func() {
+		if e.sources&IgnoreSourceGlobal != 0 {
+			if configDir, err := os.UserConfigDir(); err == nil {
+				e.global = append(e.global, e.loadFile(filepath.Join(configDir, "git", "ignore"))...)
+				e.global = append(e.global, e.loadFile(filepath.Join(configDir, "reducto", "ignore"))...)
+			}
+		}
+		if e.sources&IgnoreSourceGitignore != 0 {
+			e.global = append(e.global, e.loadFile(filepath.Join(e.root, ".git", "info", "exclude"))...)
+		}
+		for _, raw := range e.userIgnores {
+			if p, ok := compileIgnoreLine(expandHome(raw)); ok {
+				e.global = append(e.global, p)
+			}
+		}
+	})
+	return e.global
+}
+
+func expandHome(pattern string) string {
+	if !strings.HasPrefix(pattern, "~/") {
+		return pattern
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return pattern
+	}
+	return filepath.Join(home, strings.TrimPrefix(pattern, "~/"))
+}
+
+// match evaluates all applicable scopes for path (an absolute path rooted
+// at e.root) and returns whether it is ignored, and the raw pattern text
+// of the rule that decided the outcome.
+func (e *gitignoreEngine) match(path string, isDir bool) (ignored bool, rule string) {
+	relToRoot, err := filepath.Rel(e.root, path)
+	if err != nil {
+		return false, ""
+	}
+	relToRoot = filepath.ToSlash(relToRoot)
+
+	for _, p := range e.globalPatterns() {
+		if p.matches(relToRoot, isDir) {
+			ignored = !p.negate
+			rule = p.raw
+		}
+	}
+
+	dirs := []string{e.root}
+	parentRel := filepath.ToSlash(filepath.Dir(relToRoot))
+	if parentRel != "." {
+		cur := e.root
+		for _, part := range strings.Split(parentRel, "/") {
+			cur = filepath.Join(cur, part)
+			dirs = append(dirs, cur)
+		}
+	}
+
+	for _, dir := range dirs {
+		scopedRel, err := filepath.Rel(dir, filepath.Join(e.root, relToRoot))
+		if err != nil {
+			continue
+		}
+		scopedRel = filepath.ToSlash(scopedRel)
+		for _, p := range e.loadDir(dir) {
+			if p.matches(scopedRel, isDir) {
+				ignored = !p.negate
+				rule = p.raw
+			}
+		}
+	}
+
+	return ignored, rule
+}
+
+// Matcher evaluates gitignore-style rules against individual paths without
+// walking a directory tree, so callers that only need a yes/no answer for
+// one path at a time (like the MCP server's read_file handler) can reuse
+// the exact ruleset a Walker would apply. The rule it reports is the
+// deciding pattern's text only; which ignore file it came from is not
+// reported.
+type Matcher struct {
+	engine *gitignoreEngine
+}
+
+// NewWithGitignore returns a Matcher rooted at root, consulting
+// .gitignore/.dockerignore/.reductoignore files at every directory level
+// plus the user's global git/reducto ignore files and .git/info/exclude.
+func NewWithGitignore(root string) *Matcher {
+	return &Matcher{engine: newGitignoreEngine(root, IgnoreSourceAll, nil)}
+}
+
+// Matches reports whether path is ignored under m's ruleset, and which
+// rule decided that outcome.
+func (m *Matcher) Matches(path string) (ignored bool, rule string) {
+	info, err := os.Stat(path)
+	isDir := err == nil && info.IsDir()
+	return m.engine.match(path, isDir)
+}
@@ -0,0 +1,96 @@
+package walker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWalkWithGitignore(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("*.log\n!keep.log\nbuild/\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "app.py"), []byte("pass"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "debug.log"), []byte("x"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "keep.log"), []byte("x"), 0644)
+	os.MkdirAll(filepath.Join(tmpDir, "build"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, "build", "out.py"), []byte("pass"), 0644)
+
+	os.MkdirAll(filepath.Join(tmpDir, "src"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, "src", ".gitignore"), []byte("generated.py\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "src", "generated.py"), []byte("pass"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "src", "main.py"), []byte("pass"), 0644)
+
+	w := New(nil, []string{"*.py", "*.log"}, WithIgnoreSource(IgnoreSourceGitignore))
+	files, err := w.Walk(tmpDir)
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, f := range files {
+		got[f.Path] = true
+	}
+
+	want := []string{"app.py", "keep.log", filepath.Join("src", "main.py")}
+	for _, p := range want {
+		if !got[p] {
+			t.Errorf("expected %s to be walked, got %v", p, got)
+		}
+	}
+
+	dontWant := []string{"debug.log", filepath.Join("build", "out.py"), filepath.Join("src", "generated.py")}
+	for _, p := range dontWant {
+		if got[p] {
+			t.Errorf("expected %s to be ignored, got %v", p, got)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("*.tmp\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "a.tmp"), []byte("x"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "a.py"), []byte("x"), 0644)
+
+	w := New(nil, nil, WithIgnoreSource(IgnoreSourceGitignore))
+	w.ensureIgnoreEngine(tmpDir)
+
+	if ignored, rule := w.Matches(filepath.Join(tmpDir, "a.tmp")); !ignored || rule != "*.tmp" {
+		t.Errorf("Matches(a.tmp) = %v, %q; want true, *.tmp", ignored, rule)
+	}
+	if ignored, _ := w.Matches(filepath.Join(tmpDir, "a.py")); ignored {
+		t.Errorf("Matches(a.py) = true; want false")
+	}
+}
+
+func TestNewWithGitignore(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("*.tmp\n"), 0644)
+	os.MkdirAll(filepath.Join(tmpDir, "src"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, "src", ".gitignore"), []byte("generated.py\n"), 0644)
+
+	m := NewWithGitignore(tmpDir)
+
+	if ignored, rule := m.Matches(filepath.Join(tmpDir, "a.tmp")); !ignored || rule != "*.tmp" {
+		t.Errorf("Matches(a.tmp) = %v, %q; want true, *.tmp", ignored, rule)
+	}
+	if ignored, rule := m.Matches(filepath.Join(tmpDir, "src", "generated.py")); !ignored || rule != "generated.py" {
+		t.Errorf("Matches(src/generated.py) = %v, %q; want true, generated.py", ignored, rule)
+	}
+	if ignored, _ := m.Matches(filepath.Join(tmpDir, "src", "main.py")); ignored {
+		t.Errorf("Matches(src/main.py) = true; want false")
+	}
+}
+
+func TestGitignoreEngineHonorsInfoExclude(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.MkdirAll(filepath.Join(tmpDir, ".git", "info"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, ".git", "info", "exclude"), []byte("*.local\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "settings.local"), []byte("x"), 0644)
+
+	m := NewWithGitignore(tmpDir)
+	if ignored, _ := m.Matches(filepath.Join(tmpDir, "settings.local")); !ignored {
+		t.Error("expected .git/info/exclude pattern to be honored")
+	}
+}
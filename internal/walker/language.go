@@ -0,0 +1,223 @@
+package walker
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// LanguageDetector classifies files by language. Detect works from the
+// path alone (no disk access), matching the guarantee Walker.DetectLanguage
+// has always made to its callers. DetectFromContent additionally consults
+// the file's content to resolve shebangs on extensionless scripts and to
+// disambiguate extensions, like .h or .ts, that map to more than one
+// language.
+type LanguageDetector interface {
+	Detect(path string) models.Language
+	DetectFromContent(name string, head []byte) models.Language
+	RegisterLanguage(ext string, lang models.Language)
+}
+
+// ambiguousRule resolves an extension the table alone can't classify,
+// using the first few KiB of a file's content.
+type ambiguousRule func(head []byte) models.Language
+
+type shebangRule struct {
+	pattern *regexp.Regexp
+	lang    models.Language
+}
+
+type defaultLanguageDetector struct {
+	mu         sync.RWMutex
+	byExt      map[string]models.Language
+	byBasename map[string]models.Language
+	ambiguous  map[string]ambiguousRule
+	shebangs   []shebangRule
+}
+
+var cppContentPattern = regexp.MustCompile(`\b(class|namespace|template)\b|std::`)
+
+// NewLanguageDetector returns a LanguageDetector seeded with the built-in
+// extension/basename table, shebang rules, and ambiguous-extension content
+// heuristics. This is what Walker uses unless overridden via
+// WithLanguageDetector.
+func NewLanguageDetector() LanguageDetector {
+	return &defaultLanguageDetector{
+		byExt: map[string]models.Language{
+			".go":       models.LanguageGo,
+			".py":       models.LanguagePython,
+			".pyw":      models.LanguagePython,
+			".js":       models.LanguageJavaScript,
+			".jsx":      models.LanguageJavaScript,
+			".mjs":      models.LanguageJavaScript,
+			".cjs":      models.LanguageJavaScript,
+			".ts":       models.LanguageTypeScript,
+			".tsx":      models.LanguageTypeScript,
+			".rs":       models.LanguageRust,
+			".c":        models.LanguageC,
+			".h":        models.LanguageC,
+			".cc":       models.LanguageCPP,
+			".cpp":      models.LanguageCPP,
+			".cxx":      models.LanguageCPP,
+			".hpp":      models.LanguageCPP,
+			".hh":       models.LanguageCPP,
+			".java":     models.LanguageJava,
+			".kt":       models.LanguageKotlin,
+			".kts":      models.LanguageKotlin,
+			".swift":    models.LanguageSwift,
+			".rb":       models.LanguageRuby,
+			".php":      models.LanguagePHP,
+			".cs":       models.LanguageCSharp,
+			".m":        models.LanguageObjectiveC,
+			".mm":       models.LanguageObjectiveC,
+			".sh":       models.LanguageShell,
+			".bash":     models.LanguageShell,
+			".zsh":      models.LanguageShell,
+			".ps1":      models.LanguagePowerShell,
+			".yaml":     models.LanguageYAML,
+			".yml":      models.LanguageYAML,
+			".toml":     models.LanguageTOML,
+			".json":     models.LanguageJSON,
+			".xml":      models.LanguageXML,
+			".html":     models.LanguageHTML,
+			".htm":      models.LanguageHTML,
+			".css":      models.LanguageCSS,
+			".scss":     models.LanguageSCSS,
+			".less":     models.LanguageLess,
+			".md":       models.LanguageMarkdown,
+			".markdown": models.LanguageMarkdown,
+			".hcl":      models.LanguageHCL,
+			".tf":       models.LanguageHCL,
+			".proto":    models.LanguageProto,
+			".sql":      models.LanguageSQL,
+			".scala":    models.LanguageScala,
+			".pl":       models.LanguagePerl,
+			".pm":       models.LanguagePerl,
+			".lua":      models.LanguageLua,
+			".hs":       models.LanguageHaskell,
+			".ex":       models.LanguageElixir,
+			".exs":      models.LanguageElixir,
+			".erl":      models.LanguageErlang,
+			".clj":      models.LanguageClojure,
+			".cljs":     models.LanguageClojure,
+			".dart":     models.LanguageDart,
+			".r":        models.LanguageR,
+			".groovy":   models.LanguageGroovy,
+			".zig":      models.LanguageZig,
+			".nim":      models.LanguageNim,
+			".fs":       models.LanguageFSharp,
+			".fsx":      models.LanguageFSharp,
+			".vb":       models.LanguageVisualBasic,
+			".asm":      models.LanguageAssembly,
+			".s":        models.LanguageAssembly,
+			".ini":      models.LanguageINI,
+			".vim":      models.LanguageVimScript,
+		},
+		byBasename: map[string]models.Language{
+			"Dockerfile":     models.LanguageDockerfile,
+			"Makefile":       models.LanguageMakefile,
+			"makefile":       models.LanguageMakefile,
+			"go.mod":         models.LanguageGo,
+			"go.sum":         models.LanguageGo,
+			"CMakeLists.txt": models.LanguageCMake,
+			"Gemfile":        models.LanguageRuby,
+			"Rakefile":       models.LanguageRuby,
+		},
+		ambiguous: map[string]ambiguousRule{
+			// .h is shared by C and C++ headers; a handful of C++-only
+			// keywords in the first chunk of content are enough to tell.
+			".h": func(head []byte) models.Language {
+				if cppContentPattern.Match(head) {
+					return models.LanguageCPP
+				}
+				return models.LanguageC
+			},
+			// .ts is TypeScript almost everywhere, but Qt's Linguist
+			// translation files also use it and are plain XML.
+			".ts": func(head []byte) models.Language {
+				trimmed := bytes.TrimSpace(head)
+				if bytes.HasPrefix(trimmed, []byte("<?xml")) || bytes.HasPrefix(trimmed, []byte("<TS")) {
+					return models.LanguageXML
+				}
+				return models.LanguageTypeScript
+			},
+		},
+		shebangs: []shebangRule{
+			{regexp.MustCompile(`\bpython[0-9.]*\b`), models.LanguagePython},
+			{regexp.MustCompile(`\bnode\b`), models.LanguageJavaScript},
+			{regexp.MustCompile(`\b(bash|sh|zsh|ksh|dash)\b`), models.LanguageShell},
+			{regexp.MustCompile(`\bruby\b`), models.LanguageRuby},
+			{regexp.MustCompile(`\bperl\b`), models.LanguagePerl},
+		},
+	}
+}
+
+func (d *defaultLanguageDetector) Detect(path string) models.Language {
+	base := filepath.Base(path)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if lang, ok := d.byBasename[base]; ok {
+		return lang
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if lang, ok := d.byExt[ext]; ok {
+		return lang
+	}
+
+	return models.LanguageUnknown
+}
+
+func (d *defaultLanguageDetector) DetectFromContent(name string, head []byte) models.Language {
+	ext := strings.ToLower(filepath.Ext(name))
+
+	d.mu.RLock()
+	ambiguousFn, isAmbiguous := d.ambiguous[ext]
+	d.mu.RUnlock()
+	if isAmbiguous {
+		return ambiguousFn(head)
+	}
+
+	if lang := d.Detect(name); lang != models.LanguageUnknown {
+		return lang
+	}
+
+	if ext == "" {
+		if lang, ok := d.detectShebang(head); ok {
+			return lang
+		}
+	}
+
+	return models.LanguageUnknown
+}
+
+// detectShebang inspects the first line of head for a #! interpreter
+// directive and matches it against the known interpreter patterns.
+func (d *defaultLanguageDetector) detectShebang(head []byte) (models.Language, bool) {
+	line, _, _ := bufio.NewReader(bytes.NewReader(head)).ReadLine()
+	if !bytes.HasPrefix(line, []byte("#!")) {
+		return "", false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, rule := range d.shebangs {
+		if rule.pattern.Match(line) {
+			return rule.lang, true
+		}
+	}
+	return "", false
+}
+
+func (d *defaultLanguageDetector) RegisterLanguage(ext string, lang models.Language) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.byExt[strings.ToLower(ext)] = lang
+}
@@ -0,0 +1,95 @@
+package walker
+
+import (
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestDetectByExtensionAndBasename(t *testing.T) {
+	d := NewLanguageDetector()
+
+	tests := []struct {
+		path     string
+		expected models.Language
+	}{
+		{"main.rs", models.LanguageRust},
+		{"pom.xml", models.LanguageXML},
+		{"Dockerfile", models.LanguageDockerfile},
+		{"Makefile", models.LanguageMakefile},
+		{"go.mod", models.LanguageGo},
+		{"config.yaml", models.LanguageYAML},
+		{"notes.txt", models.LanguageUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := d.Detect(tt.path); got != tt.expected {
+				t.Errorf("Detect(%s) = %v, want %v", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectFromContentResolvesShebang(t *testing.T) {
+	d := NewLanguageDetector()
+
+	tests := []struct {
+		name string
+		head string
+		want models.Language
+	}{
+		{"deploy", "#!/usr/bin/env python3\nprint('hi')\n", models.LanguagePython},
+		{"run", "#!/bin/bash\necho hi\n", models.LanguageShell},
+		{"noshebang", "just some text\n", models.LanguageUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.DetectFromContent(tt.name, []byte(tt.head)); got != tt.want {
+				t.Errorf("DetectFromContent(%s) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectFromContentDisambiguatesHeaderFiles(t *testing.T) {
+	d := NewLanguageDetector()
+
+	cHeader := "#ifndef FOO_H\n#define FOO_H\nint foo(void);\n#endif\n"
+	if got := d.DetectFromContent("foo.h", []byte(cHeader)); got != models.LanguageC {
+		t.Errorf("expected plain .h header to be classified as C, got %v", got)
+	}
+
+	cppHeader := "#pragma once\nnamespace foo {\nclass Widget {};\n}\n"
+	if got := d.DetectFromContent("foo.h", []byte(cppHeader)); got != models.LanguageCPP {
+		t.Errorf("expected .h header using namespace/class to be classified as C++, got %v", got)
+	}
+}
+
+func TestDetectFromContentDisambiguatesTSFiles(t *testing.T) {
+	d := NewLanguageDetector()
+
+	if got := d.DetectFromContent("app.ts", []byte("export const x: number = 1\n")); got != models.LanguageTypeScript {
+		t.Errorf("expected .ts source to be classified as TypeScript, got %v", got)
+	}
+
+	qtTranslation := `<?xml version="1.0"?><TS version="2.1"></TS>`
+	if got := d.DetectFromContent("app.ts", []byte(qtTranslation)); got != models.LanguageXML {
+		t.Errorf("expected Qt Linguist .ts file to be classified as XML, got %v", got)
+	}
+}
+
+func TestRegisterLanguageOverridesTable(t *testing.T) {
+	d := NewLanguageDetector()
+
+	if got := d.Detect("build.gradle"); got != models.LanguageUnknown {
+		t.Fatalf("expected .gradle to start out unknown, got %v", got)
+	}
+
+	d.RegisterLanguage(".gradle", models.LanguageGroovy)
+
+	if got := d.Detect("build.gradle"); got != models.LanguageGroovy {
+		t.Errorf("RegisterLanguage override not applied, got %v", got)
+	}
+}
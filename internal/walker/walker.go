@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/alexkarsten/reducto/pkg/models"
 	"golang.org/x/sync/errgroup"
@@ -18,44 +19,87 @@ import (
 type Walker struct {
 	excludePatterns []string
 	includePatterns []string
+
+	ignoreSources IgnoreSource
+	ignoreEngine  *gitignoreEngine
+
+	cache     Cache
+	bytesRead atomic.Int64
+
+	detector LanguageDetector
 }
 
-func New(excludePatterns, includePatterns []string) *Walker {
-	return &Walker{
-		excludePatterns: excludePatterns,
-		includePatterns: includePatterns,
+// Option configures optional behavior on a Walker constructed via New.
+type Option func(*Walker)
+
+// WithIgnoreSource enables real gitignore-style matching in addition to the
+// flat exclude/include patterns, consulting .gitignore/.dockerignore/
+// .reductoignore files (as selected by src) at every directory level.
+func WithIgnoreSource(src IgnoreSource) Option {
+	return func(w *Walker) {
+		w.ignoreSources = src
 	}
 }
 
-func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
-	var filePaths []string
+// WithCache makes Walk consult c before re-reading and re-hashing a file
+// from disk, keyed by (path, mtime, size).
+func WithCache(c Cache) Option {
+	return func(w *Walker) {
+		w.cache = c
+	}
+}
 
-	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
+// WithLanguageDetector overrides the LanguageDetector DetectLanguage and
+// DetectLanguageFromContent delegate to, in place of the built-in table
+// returned by NewLanguageDetector.
+func WithLanguageDetector(d LanguageDetector) Option {
+	return func(w *Walker) {
+		w.detector = d
+	}
+}
 
-		if d.IsDir() {
-			if w.shouldExcludeDir(path) {
-				return fs.SkipDir
-			}
-			return nil
-		}
+func New(excludePatterns, includePatterns []string, opts ...Option) *Walker {
+	w := &Walker{
+		excludePatterns: excludePatterns,
+		includePatterns: includePatterns,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
 
-		if w.shouldExcludeFile(path) {
-			return nil
-		}
+// ensureIgnoreEngine lazily builds the gitignore engine rooted at root the
+// first time it's needed, since the root directory isn't known at New time.
+func (w *Walker) ensureIgnoreEngine(root string) {
+	if w.ignoreSources == 0 || w.ignoreEngine != nil {
+		return
+	}
+	w.ignoreEngine = newGitignoreEngine(root, w.ignoreSources, w.excludePatterns)
+}
 
-		if !w.shouldIncludeFile(path) {
-			return nil
+// Matches reports whether path would be skipped by the walker's ignore
+// rules, and which rule made that determination, so callers like the MCP
+// server can explain why a file was excluded.
+func (w *Walker) Matches(path string) (ignored bool, rule string) {
+	if w.ignoreEngine == nil {
+		if w.shouldExcludeDir(path) || w.shouldExcludeFile(path) {
+			return true, ""
 		}
+		return false, ""
+	}
 
-		filePaths = append(filePaths, path)
-		return nil
-	})
+	info, err := os.Stat(path)
+	isDir := err == nil && info.IsDir()
+	return w.ignoreEngine.match(path, isDir)
+}
 
+func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
+	w.ensureIgnoreEngine(root)
+
+	filePaths, err := w.collectPaths(root)
 	if err != nil {
-		return nil, fmt.Errorf("failed to walk directory: %w", err)
+		return nil, err
 	}
 
 	var files []models.FileInfo
@@ -74,24 +118,13 @@ func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
 			default:
 			}
 
-			content, err := os.ReadFile(path)
+			info, err := w.readFileInfo(path, root)
 			if err != nil {
-				return fmt.Errorf("failed to read file %s: %w", path, err)
+				return err
 			}
 
-			relPath, err := filepath.Rel(root, path)
-			if err != nil {
-				relPath = path
-			}
-
-			hash := sha256.Sum256(content)
-
 			mu.Lock()
-			files = append(files, models.FileInfo{
-				Path:    relPath,
-				Content: string(content),
-				Hash:    hex.EncodeToString(hash[:]),
-			})
+			files = append(files, info)
 			mu.Unlock()
 
 			return nil
@@ -105,6 +138,119 @@ func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
 	return files, nil
 }
 
+// WalkStream walks root like Walk, but invokes fn for each file as it's
+// found instead of collecting them into a slice, so callers relaying
+// results over a wire protocol (like the MCP server's streaming
+// list_files) don't need to buffer the whole project in memory. Unlike
+// Walk, files are read sequentially in directory-tree order rather than
+// concurrently, since fn's side effect (emitting one notification per
+// file) needs to preserve call order. fn returning an error stops the walk
+// and that error is returned from WalkStream.
+func (w *Walker) WalkStream(root string, fn func(models.FileInfo) error) error {
+	w.ensureIgnoreEngine(root)
+
+	filePaths, err := w.collectPaths(root)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range filePaths {
+		info, err := w.readFileInfo(path, root)
+		if err != nil {
+			return err
+		}
+		if err := fn(info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectPaths walks root and returns the paths of files that pass the
+// walker's exclude/include/gitignore filters, without reading any of them.
+func (w *Walker) collectPaths(root string) ([]string, error) {
+	var filePaths []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if w.shouldExcludeDir(path) {
+				return fs.SkipDir
+			}
+			if w.ignoreEngine != nil {
+				if ignored, _ := w.ignoreEngine.match(path, true); ignored {
+					return fs.SkipDir
+				}
+			}
+			return nil
+		}
+
+		if w.shouldExcludeFile(path) {
+			return nil
+		}
+
+		if w.ignoreEngine != nil {
+			if ignored, _ := w.ignoreEngine.match(path, false); ignored {
+				return nil
+			}
+		}
+
+		if !w.shouldIncludeFile(path) {
+			return nil
+		}
+
+		filePaths = append(filePaths, path)
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+	return filePaths, nil
+}
+
+// readFileInfo stats and (if needed) reads path, consulting w.cache first,
+// and returns it as a models.FileInfo with its Path relative to root.
+func (w *Walker) readFileInfo(path, root string) (models.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return models.FileInfo{}, fmt.Errorf("failed to stat file %s: %w", path, err)
+	}
+
+	var hash, content string
+	var hit bool
+	if w.cache != nil {
+		hash, content, hit = w.cache.Get(path, info.ModTime(), info.Size())
+	}
+
+	if !hit {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return models.FileInfo{}, fmt.Errorf("failed to read file %s: %w", path, err)
+		}
+
+		sum := sha256.Sum256(raw)
+		hash = hex.EncodeToString(sum[:])
+		content = string(raw)
+		w.bytesRead.Add(int64(len(raw)))
+
+		if w.cache != nil {
+			w.cache.Put(path, info.ModTime(), info.Size(), hash, content)
+		}
+	}
+
+	relPath, err := filepath.Rel(root, path)
+	if err != nil {
+		relPath = path
+	}
+
+	return models.FileInfo{Path: relPath, Content: content, Hash: hash}, nil
+}
+
 func (w *Walker) shouldExcludeDir(path string) bool {
 	name := filepath.Base(path)
 	for _, pattern := range w.excludePatterns {
@@ -162,20 +308,31 @@ func (w *Walker) shouldIncludeFile(path string) bool {
 	return false
 }
 
-func (w *Walker) DetectLanguage(path string) models.Language {
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".py":
-		return models.LanguagePython
-	case ".js":
-		return models.LanguageJavaScript
-	case ".ts", ".tsx":
-		return models.LanguageTypeScript
-	case ".go":
-		return models.LanguageGo
-	default:
-		return models.LanguageUnknown
+// ensureDetector lazily builds the default LanguageDetector the first time
+// it's needed, since most Walkers never override it via WithLanguageDetector.
+func (w *Walker) ensureDetector() LanguageDetector {
+	if w.detector == nil {
+		w.detector = NewLanguageDetector()
 	}
+	return w.detector
+}
+
+// LanguageDetector returns the detector Walker delegates to, so callers can
+// add project-specific mappings via its RegisterLanguage method.
+func (w *Walker) LanguageDetector() LanguageDetector {
+	return w.ensureDetector()
+}
+
+func (w *Walker) DetectLanguage(path string) models.Language {
+	return w.ensureDetector().Detect(path)
+}
+
+// DetectLanguageFromContent is DetectLanguage's content-aware counterpart:
+// it additionally uses head (the file's leading bytes) to resolve shebangs
+// on extensionless files and to disambiguate extensions like .h or .ts
+// that the table alone maps to more than one language.
+func (w *Walker) DetectLanguageFromContent(path string, head []byte) models.Language {
+	return w.ensureDetector().DetectFromContent(path, head)
 }
 
 func (w *Walker) CountLines(content string) int {
@@ -199,6 +356,13 @@ func (w *Walker) GetProjectStats(root string) (*ProjectStats, error) {
 		stats.TotalLines += w.CountLines(f.Content)
 	}
 
+	if w.cache != nil {
+		cs := w.cache.Stats()
+		stats.CacheHits = cs.Hits
+		stats.CacheMisses = cs.Misses
+	}
+	stats.BytesRead = w.bytesRead.Load()
+
 	return stats, nil
 }
 
@@ -206,4 +370,11 @@ type ProjectStats struct {
 	TotalFiles int
 	TotalLines int
 	ByLanguage map[models.Language]int
+
+	// CacheHits and CacheMisses are only meaningful when the Walker was
+	// constructed WithCache; they stay zero otherwise. BytesRead counts the
+	// bytes every Walk of this Walker has read from disk, cache or not.
+	CacheHits   int64
+	CacheMisses int64
+	BytesRead   int64
 }
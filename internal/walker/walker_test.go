@@ -5,7 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
-	"github.com/alexkarsten/dehydrate/pkg/models"
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 func TestWalk(t *testing.T) {
@@ -108,8 +108,9 @@ func TestDetectLanguage(t *testing.T) {
 		{"app.ts", models.LanguageTypeScript},
 		{"component.tsx", models.LanguageTypeScript},
 		{"main.go", models.LanguageGo},
-		{"README.md", models.LanguageUnknown},
-		{"data.json", models.LanguageUnknown},
+		{"README.md", models.LanguageMarkdown},
+		{"data.json", models.LanguageJSON},
+		{"unknown.xyz", models.LanguageUnknown},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,53 @@
+package walker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestWalkStreamVisitsEachFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "a.py"), []byte("print('a')"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "b.py"), []byte("print('b')"), 0644)
+	os.Mkdir(filepath.Join(tmpDir, "node_modules"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, "node_modules", "c.js"), []byte("console.log('c')"), 0644)
+
+	w := New([]string{"node_modules"}, []string{"*.py"})
+
+	var got []string
+	err := w.WalkStream(tmpDir, func(f models.FileInfo) error {
+		got = append(got, f.Path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WalkStream() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("expected 2 files, got %v", got)
+	}
+}
+
+func TestWalkStreamStopsOnCallbackError(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "a.py"), []byte("print('a')"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "b.py"), []byte("print('b')"), 0644)
+
+	w := New(nil, []string{"*.py"})
+	sentinel := errors.New("stop")
+
+	visited := 0
+	err := w.WalkStream(tmpDir, func(f models.FileInfo) error {
+		visited++
+		return sentinel
+	})
+	if !errors.Is(err, sentinel) {
+		t.Errorf("expected the callback's error to propagate, got %v", err)
+	}
+	if visited != 1 {
+		t.Errorf("expected the walk to stop after the first file, got %d visited", visited)
+	}
+}
@@ -0,0 +1,334 @@
+package walker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+	"github.com/fsnotify/fsnotify"
+)
+
+// ChangeSet describes a debounced batch of filesystem changes observed by
+// Walker.Watch, grouped the same way editors tend to save: several writes
+// to a handful of files within a short window.
+type ChangeSet struct {
+	Added    []models.FileInfo
+	Modified []models.FileInfo
+	Removed  []models.FileInfo
+	Renamed  []Rename
+}
+
+// Rename describes a file whose path changed within a single debounced
+// batch, detected by correlating a Removed and an Added entry that share
+// the same content hash. Only populated when WithRenameDetection is set.
+type Rename struct {
+	OldPath string
+	Path    string
+	Hash    string
+}
+
+func (cs *ChangeSet) empty() bool {
+	return len(cs.Added) == 0 && len(cs.Modified) == 0 && len(cs.Removed) == 0 && len(cs.Renamed) == 0
+}
+
+func mergeChangeSets(dst, src *ChangeSet) {
+	dst.Added = append(dst.Added, src.Added...)
+	dst.Modified = append(dst.Modified, src.Modified...)
+	dst.Removed = append(dst.Removed, src.Removed...)
+	dst.Renamed = append(dst.Renamed, src.Renamed...)
+}
+
+// correlateRenames scans cs for Added/Removed pairs that share a content
+// hash within the same batch and re-files them as Renamed entries. Matches
+// are made in Removed order so repeated hashes (e.g. several empty files)
+// pair up deterministically rather than all collapsing onto one Added entry.
+func correlateRenames(cs *ChangeSet) {
+	if len(cs.Added) == 0 || len(cs.Removed) == 0 {
+		return
+	}
+
+	removedByHash := make(map[string][]int, len(cs.Removed))
+	for i, f := range cs.Removed {
+		removedByHash[f.Hash] = append(removedByHash[f.Hash], i)
+	}
+
+	removedUsed := make(map[int]bool, len(cs.Removed))
+	var remainingAdded []models.FileInfo
+	for _, added := range cs.Added {
+		idxs := removedByHash[added.Hash]
+		matched := -1
+		for _, idx := range idxs {
+			if !removedUsed[idx] {
+				matched = idx
+				break
+			}
+		}
+		if matched == -1 {
+			remainingAdded = append(remainingAdded, added)
+			continue
+		}
+		removedUsed[matched] = true
+		cs.Renamed = append(cs.Renamed, Rename{
+			OldPath: cs.Removed[matched].Path,
+			Path:    added.Path,
+			Hash:    added.Hash,
+		})
+	}
+
+	remainingRemoved := make([]models.FileInfo, 0, len(cs.Removed)-len(removedUsed))
+	for i, f := range cs.Removed {
+		if !removedUsed[i] {
+			remainingRemoved = append(remainingRemoved, f)
+		}
+	}
+
+	cs.Added = remainingAdded
+	cs.Removed = remainingRemoved
+}
+
+// WatchOption configures Walker.Watch.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	debounce      time.Duration
+	detectRenames bool
+}
+
+// WithDebounce overrides the default 100ms quiet period used to coalesce
+// rapid successive writes into a single ChangeSet.
+func WithDebounce(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.debounce = d
+	}
+}
+
+// WithRenameDetection enables correlating a Removed and an Added entry that
+// share a content hash within the same debounced batch into a single
+// ChangeSet.Renamed entry instead of reporting them as separate add/remove
+// pairs.
+func WithRenameDetection(enabled bool) WatchOption {
+	return func(o *watchOptions) {
+		o.detectRenames = enabled
+	}
+}
+
+// Watch performs an initial Walk of root and then keeps the resulting file
+// index live, emitting debounced ChangeSet batches on the returned channel
+// as files are created, modified, or removed. The same include/exclude (and
+// gitignore, if configured) rules used by Walk apply to watched events.
+func (w *Walker) Watch(ctx context.Context, root string, opts ...WatchOption) (<-chan ChangeSet, error) {
+	wOpts := watchOptions{debounce: 100 * time.Millisecond}
+	for _, o := range opts {
+		o(&wOpts)
+	}
+
+	files, err := w.Walk(root)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]models.FileInfo, len(files))
+	for _, f := range files {
+		index[f.Path] = f
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create filesystem watcher: %w", err)
+	}
+
+	if err := w.addRecursiveWatches(fsw, root); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", root, err)
+	}
+
+	out := make(chan ChangeSet, 1)
+	go w.watchLoop(ctx, fsw, root, index, wOpts, out)
+
+	return out, nil
+}
+
+func (w *Walker) addRecursiveWatches(fsw *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if w.shouldExcludeDir(path) {
+			return fs.SkipDir
+		}
+		if w.ignoreEngine != nil {
+			if ignored, _ := w.ignoreEngine.match(path, true); ignored {
+				return fs.SkipDir
+			}
+		}
+		return fsw.Add(path)
+	})
+}
+
+func (w *Walker) watchLoop(ctx context.Context, fsw *fsnotify.Watcher, root string, index map[string]models.FileInfo, opts watchOptions, out chan<- ChangeSet) {
+	defer fsw.Close()
+
+	var mu sync.Mutex
+	dirty := make(map[string]struct{})
+	var timer *time.Timer
+
+	pendingMu := sync.Mutex{}
+	var pending *ChangeSet
+	signal := make(chan struct{}, 1)
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signal:
+			}
+
+			pendingMu.Lock()
+			cs := pending
+			pending = nil
+			pendingMu.Unlock()
+
+			if cs == nil || cs.empty() {
+				continue
+			}
+
+			select {
+			case out <- *cs:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	flush := func() {
+		mu.Lock()
+		paths := make([]string, 0, len(dirty))
+		for p := range dirty {
+			paths = append(paths, p)
+		}
+		dirty = make(map[string]struct{})
+		mu.Unlock()
+
+		cs := w.diffPaths(root, paths, index)
+		if opts.detectRenames {
+			correlateRenames(cs)
+		}
+		if cs.empty() {
+			return
+		}
+
+		pendingMu.Lock()
+		if pending == nil {
+			pending = cs
+		} else {
+			mergeChangeSets(pending, cs)
+		}
+		pendingMu.Unlock()
+
+		select {
+		case signal <- struct{}{}:
+		default:
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+
+		case ev, ok := <-fsw.Events:
+			if !ok {
+				return
+			}
+
+			if ev.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					w.addRecursiveWatches(fsw, ev.Name)
+				}
+			}
+
+			mu.Lock()
+			dirty[ev.Name] = struct{}{}
+			mu.Unlock()
+
+			if timer == nil {
+				timer = time.AfterFunc(opts.debounce, flush)
+			} else {
+				timer.Reset(opts.debounce)
+			}
+
+		case _, ok := <-fsw.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// diffPaths re-reads and re-hashes each touched path, compares the hash
+// against the live index so a same-content rewrite is not reported, and
+// returns the resulting ChangeSet while updating index in place.
+func (w *Walker) diffPaths(root string, paths []string, index map[string]models.FileInfo) *ChangeSet {
+	cs := &ChangeSet{}
+
+	for _, path := range paths {
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			relPath = path
+		}
+
+		if w.shouldExcludeDir(path) || w.shouldExcludeFile(path) {
+			continue
+		}
+		if !w.shouldIncludeFile(path) {
+			continue
+		}
+		if w.ignoreEngine != nil {
+			if ignored, _ := w.ignoreEngine.match(path, false); ignored {
+				continue
+			}
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if existing, ok := index[relPath]; ok {
+				delete(index, relPath)
+				cs.Removed = append(cs.Removed, existing)
+			}
+			continue
+		}
+
+		sum := sha256.Sum256(content)
+		hash := hex.EncodeToString(sum[:])
+
+		existing, existed := index[relPath]
+		if existed && existing.Hash == hash {
+			continue
+		}
+
+		info := models.FileInfo{Path: relPath, Content: string(content), Hash: hash}
+		index[relPath] = info
+
+		if existed {
+			cs.Modified = append(cs.Modified, info)
+		} else {
+			cs.Added = append(cs.Added, info)
+		}
+	}
+
+	return cs
+}
@@ -0,0 +1,168 @@
+package walker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchDetectsChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "a.py"), []byte("pass"), 0644)
+
+	w := New(nil, []string{"*.py"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := w.Watch(ctx, tmpDir, WithDebounce(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	os.WriteFile(filepath.Join(tmpDir, "b.py"), []byte("pass"), 0644)
+
+	select {
+	case cs := <-changes:
+		if len(cs.Added) != 1 || cs.Added[0].Path != "b.py" {
+			t.Errorf("expected b.py added, got %+v", cs)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for change set")
+	}
+}
+
+func waitForChangeSet(t *testing.T, changes <-chan ChangeSet) ChangeSet {
+	t.Helper()
+	select {
+	case cs := <-changes:
+		return cs
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for change set")
+		return ChangeSet{}
+	}
+}
+
+func TestWatchDetectsModified(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "a.py"), []byte("pass"), 0644)
+
+	w := New(nil, []string{"*.py"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := w.Watch(ctx, tmpDir, WithDebounce(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	os.WriteFile(filepath.Join(tmpDir, "a.py"), []byte("pass2"), 0644)
+
+	cs := waitForChangeSet(t, changes)
+	if len(cs.Modified) != 1 || cs.Modified[0].Path != "a.py" {
+		t.Errorf("expected a.py modified, got %+v", cs)
+	}
+}
+
+func TestWatchSuppressesNoOpWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "a.py")
+	os.WriteFile(path, []byte("pass"), 0644)
+
+	w := New(nil, []string{"*.py"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := w.Watch(ctx, tmpDir, WithDebounce(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Same content rewritten, e.g. a re-save with no actual change.
+	os.WriteFile(path, []byte("pass"), 0644)
+	select {
+	case cs := <-changes:
+		t.Fatalf("expected no change set for a same-content rewrite, got %+v", cs)
+	case <-time.After(200 * time.Millisecond):
+	}
+	// A real change afterwards is reported as the only modification.
+	os.WriteFile(path, []byte("pass-changed"), 0644)
+
+	cs := waitForChangeSet(t, changes)
+	if len(cs.Modified) != 1 || cs.Modified[0].Path != "a.py" {
+		t.Errorf("expected only the real modification, got %+v", cs)
+	}
+}
+
+func TestWatchDetectsDeleted(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "a.py")
+	os.WriteFile(path, []byte("pass"), 0644)
+
+	w := New(nil, []string{"*.py"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := w.Watch(ctx, tmpDir, WithDebounce(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	os.Remove(path)
+
+	cs := waitForChangeSet(t, changes)
+	if len(cs.Removed) != 1 || cs.Removed[0].Path != "a.py" {
+		t.Errorf("expected a.py removed, got %+v", cs)
+	}
+}
+
+func TestWatchDetectsRenamed(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldPath := filepath.Join(tmpDir, "old.py")
+	os.WriteFile(oldPath, []byte("pass"), 0644)
+
+	w := New(nil, []string{"*.py"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := w.Watch(ctx, tmpDir, WithDebounce(20*time.Millisecond), WithRenameDetection(true))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	os.Rename(oldPath, filepath.Join(tmpDir, "new.py"))
+
+	cs := waitForChangeSet(t, changes)
+	if len(cs.Renamed) != 1 || cs.Renamed[0].Path != "new.py" || cs.Renamed[0].OldPath != "old.py" {
+		t.Errorf("expected old.py renamed to new.py, got %+v", cs)
+	}
+}
+
+func TestWatchWithoutRenameDetectionReportsAddRemove(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldPath := filepath.Join(tmpDir, "old.py")
+	os.WriteFile(oldPath, []byte("pass"), 0644)
+
+	w := New(nil, []string{"*.py"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, err := w.Watch(ctx, tmpDir, WithDebounce(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	os.Rename(oldPath, filepath.Join(tmpDir, "new.py"))
+
+	cs := waitForChangeSet(t, changes)
+	if len(cs.Renamed) != 0 {
+		t.Errorf("expected no renames without WithRenameDetection, got %+v", cs.Renamed)
+	}
+	if len(cs.Added) != 1 || cs.Added[0].Path != "new.py" {
+		t.Errorf("expected new.py added, got %+v", cs)
+	}
+	if len(cs.Removed) != 1 || cs.Removed[0].Path != "old.py" {
+		t.Errorf("expected old.py removed, got %+v", cs)
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// FileInfo is a file's content and metadata as seen by walker.Walker: a
+// root-relative path, its full text, and a content hash used to detect
+// whether it changed since the last time it was read.
+type FileInfo struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	Hash    string `json:"hash"`
+}
+
+// Symbol is a named declaration (function, class, method, ...) found in a
+// file, whether by parser's regex heuristics, treesitter's AST queries, or
+// an LSP server's textDocument/documentSymbol. ContainerName is set when a
+// symbol is nested inside another, e.g. a method inside a class.
+type Symbol struct {
+	Name          string `json:"name" yaml:"name"`
+	Type          string `json:"type" yaml:"type"`
+	File          string `json:"file" yaml:"file,omitempty"`
+	StartLine     int    `json:"start_line" yaml:"start_line"`
+	EndLine       int    `json:"end_line" yaml:"end_line"`
+	Signature     string `json:"signature,omitempty" yaml:"signature,omitempty"`
+	ContainerName string `json:"container_name,omitempty" yaml:"container_name,omitempty"`
+}
+
+// CodeBlock is the source text of one top-level symbol along with its
+// complexity metrics and, when a Blamer was available, the git history of
+// whoever last touched it.
+type CodeBlock struct {
+	File         string            `json:"file"`
+	StartLine    int               `json:"start_line"`
+	EndLine      int               `json:"end_line"`
+	Content      string            `json:"content"`
+	Language     Language          `json:"language"`
+	SymbolType   string            `json:"symbol_type"`
+	SymbolName   string            `json:"symbol_name"`
+	Metrics      ComplexityMetrics `json:"metrics"`
+	LastAuthor   string            `json:"last_author,omitempty"`
+	LastCommit   string            `json:"last_commit,omitempty"`
+	LastModified time.Time         `json:"last_modified,omitempty"`
+}
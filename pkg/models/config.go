@@ -29,16 +29,36 @@ type SidecarConfig struct {
 	AutoInstall     bool `mapstructure:"auto_install" yaml:"auto_install"`
 }
 
+// LSPCacheConfig tunes the size-and-memory-bounded LRU cache that
+// lsp.Manager keeps in front of expensive calls like FindReferences and
+// GoToDefinition. A zero MaxBytesMB or TTLSeconds leaves that setting at
+// its built-in default.
+type LSPCacheConfig struct {
+	Enabled    bool `mapstructure:"enabled" yaml:"enabled"`
+	MaxBytesMB int  `mapstructure:"max_bytes_mb" yaml:"max_bytes_mb"`
+	TTLSeconds int  `mapstructure:"ttl_seconds" yaml:"ttl_seconds"`
+}
+
 type ComplexityThresholds struct {
 	CyclomaticComplexity int `mapstructure:"cyclomatic_complexity" yaml:"cyclomatic_complexity"`
 	CognitiveComplexity  int `mapstructure:"cognitive_complexity" yaml:"cognitive_complexity"`
 	LinesOfCode          int `mapstructure:"lines_of_code" yaml:"lines_of_code"`
 }
 
+// HubConfig points reducto at the remote index used by `reducto hub` to
+// discover installable pattern/idiom/dedup/check packs.
+type HubConfig struct {
+	IndexURL    string   `mapstructure:"index_url" yaml:"index_url"`
+	Branch      string   `mapstructure:"branch" yaml:"branch"`
+	TrustedKeys []string `mapstructure:"trusted_keys" yaml:"trusted_keys"`
+}
+
 type Config struct {
 	Models               ModelsConfig         `mapstructure:"models" yaml:"models"`
 	Sidecar              SidecarConfig        `mapstructure:"sidecar" yaml:"sidecar"`
+	LSPCache             LSPCacheConfig       `mapstructure:"lsp_cache" yaml:"lsp_cache"`
 	ComplexityThresholds ComplexityThresholds `mapstructure:"complexity_thresholds" yaml:"complexity_thresholds"`
+	Hub                  HubConfig            `mapstructure:"hub" yaml:"hub"`
 	PreApprove           bool                 `mapstructure:"pre_approve" yaml:"pre_approve"`
 	CommitChanges        bool                 `mapstructure:"commit_changes" yaml:"commit_changes"`
 	Report               bool                 `mapstructure:"report" yaml:"report"`
@@ -0,0 +1,15 @@
+package models
+
+// Diagnostic is one lint/vet finding normalized from a linter.Backend's
+// tool-specific output into a single shape, so run_lint returns the same
+// fields regardless of which tool (golangci-lint, ruff, eslint, ...)
+// produced them.
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message"`
+	Source   string `json:"source"`
+}
@@ -0,0 +1,65 @@
+package models
+
+// Language identifies a file's programming or markup language. It's a
+// plain string alias rather than a closed enum, so walker.LanguageDetector
+// and its RegisterLanguage overrides can introduce new values without
+// editing this file.
+type Language string
+
+const (
+	LanguageUnknown    Language = "unknown"
+	LanguageGo         Language = "go"
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "javascript"
+	LanguageTypeScript Language = "typescript"
+)
+
+// Additional Language values beyond the original four, used by the LSP
+// client registry to auto-detect tooling for more ecosystems, and by
+// walker.LanguageDetector's expanded extension table.
+const (
+	LanguageRust        Language = "rust"
+	LanguageC           Language = "c"
+	LanguageCPP         Language = "cpp"
+	LanguageJava        Language = "java"
+	LanguageKotlin      Language = "kotlin"
+	LanguageSwift       Language = "swift"
+	LanguageRuby        Language = "ruby"
+	LanguagePHP         Language = "php"
+	LanguageCSharp      Language = "csharp"
+	LanguageObjectiveC  Language = "objective-c"
+	LanguageShell       Language = "shell"
+	LanguagePowerShell  Language = "powershell"
+	LanguageYAML        Language = "yaml"
+	LanguageTOML        Language = "toml"
+	LanguageJSON        Language = "json"
+	LanguageXML         Language = "xml"
+	LanguageHTML        Language = "html"
+	LanguageCSS         Language = "css"
+	LanguageSCSS        Language = "scss"
+	LanguageLess        Language = "less"
+	LanguageMarkdown    Language = "markdown"
+	LanguageDockerfile  Language = "dockerfile"
+	LanguageMakefile    Language = "makefile"
+	LanguageCMake       Language = "cmake"
+	LanguageHCL         Language = "hcl"
+	LanguageProto       Language = "proto"
+	LanguageSQL         Language = "sql"
+	LanguageScala       Language = "scala"
+	LanguagePerl        Language = "perl"
+	LanguageLua         Language = "lua"
+	LanguageHaskell     Language = "haskell"
+	LanguageElixir      Language = "elixir"
+	LanguageErlang      Language = "erlang"
+	LanguageClojure     Language = "clojure"
+	LanguageDart        Language = "dart"
+	LanguageR           Language = "r"
+	LanguageGroovy      Language = "groovy"
+	LanguageZig         Language = "zig"
+	LanguageNim         Language = "nim"
+	LanguageFSharp      Language = "fsharp"
+	LanguageVisualBasic Language = "visualbasic"
+	LanguageAssembly    Language = "assembly"
+	LanguageINI         Language = "ini"
+	LanguageVimScript   Language = "vimscript"
+)
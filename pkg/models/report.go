@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// FileChange describes one file's contribution to a refactor: its path,
+// a human-readable description of what changed, and the full before/after
+// content so callers can regenerate diffs without touching disk again.
+type FileChange struct {
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Original    string `json:"original"`
+	Modified    string `json:"modified"`
+}
+
+// ComplexityMetrics summarizes a piece of code's size and branching shape.
+type ComplexityMetrics struct {
+	LinesOfCode          int     `json:"lines_of_code"`
+	CyclomaticComplexity int     `json:"cyclomatic_complexity"`
+	CognitiveComplexity  int     `json:"cognitive_complexity"`
+	MaintainabilityIndex float64 `json:"maintainability_index"`
+}
+
+// MetricsDelta captures how ComplexityMetrics moved between two snapshots,
+// typically before and after a refactor.
+type MetricsDelta struct {
+	CyclomaticComplexityDelta int     `json:"cyclomatic_complexity_delta"`
+	CognitiveComplexityDelta  int     `json:"cognitive_complexity_delta"`
+	MaintainabilityIndexDelta float64 `json:"maintainability_index_delta"`
+}
+
+// RefactorResult is the outcome of applying a refactor: the files it
+// touched and the complexity metrics measured before and after.
+type RefactorResult struct {
+	SessionID     string            `json:"session_id"`
+	Changes       []FileChange      `json:"changes"`
+	MetricsBefore ComplexityMetrics `json:"metrics_before"`
+	MetricsAfter  ComplexityMetrics `json:"metrics_after"`
+}
+
+// RefactorPlan is a proposed, not-yet-applied RefactorResult, used by
+// dry-run reporting to show what a command would change.
+type RefactorPlan struct {
+	SessionID   string       `json:"session_id"`
+	Changes     []FileChange `json:"changes"`
+	Description string       `json:"description"`
+}
+
+// Report is the summarized, reporter-facing view of a RefactorResult.
+type Report struct {
+	SessionID     string       `json:"session_id"`
+	GeneratedAt   time.Time    `json:"generated_at"`
+	LOCBefore     int          `json:"loc_before"`
+	LOCAfter      int          `json:"loc_after"`
+	LOCReduced    int          `json:"loc_reduced"`
+	FilesModified []string     `json:"files_modified"`
+	MetricsDelta  MetricsDelta `json:"metrics_delta"`
+}